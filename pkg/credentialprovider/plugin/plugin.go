@@ -48,6 +48,20 @@ import (
 const (
 	globalCacheKey     = "global"
 	cachePurgeInterval = time.Minute * 15
+
+	// defaultExecTimeout bounds a single invocation of an exec plugin. This should leave enough
+	// head room in case a plugin needs to retry a failed request while ensuring an exec plugin
+	// does not run forever.
+	defaultExecTimeout = 1 * time.Minute
+
+	// execFailureThreshold is the number of consecutive exec plugin failures after which
+	// the provider is temporarily skipped instead of being invoked for every image pull.
+	execFailureThreshold = 5
+
+	// execFailureCooldown is how long a provider is skipped once it has tripped
+	// execFailureThreshold. Image pulls during the cooldown proceed without credentials
+	// from this provider.
+	execFailureCooldown = 30 * time.Second
 )
 
 var (
@@ -135,6 +149,7 @@ func newPluginProvider(pluginBinDir string, provider kubeletconfig.CredentialPro
 			pluginBinDir: pluginBinDir,
 			args:         provider.Args,
 			envVars:      provider.Env,
+			execTimeout:  defaultExecTimeout,
 		},
 	}, nil
 }
@@ -164,6 +179,14 @@ type pluginProvider struct {
 
 	// lastCachePurge is the last time cache is cleaned for expired entries.
 	lastCachePurge time.Time
+
+	// consecutiveFailures counts exec plugin failures since the last success,
+	// used to decide when to trip the failure cooldown.
+	consecutiveFailures int
+
+	// disabledUntil, when in the future, indicates the provider is skipped
+	// because it tripped execFailureThreshold.
+	disabledUntil time.Time
 }
 
 // cacheEntry is the cache object that will be stored in cache.Store.
@@ -207,6 +230,11 @@ func (p *pluginProvider) Provide(image string) credentialprovider.DockerConfig {
 		return cachedConfig
 	}
 
+	if p.skipDueToFailures() {
+		klog.V(4).Infof("Skipping external registry credential provider, in cooldown after consecutive failures")
+		return credentialprovider.DockerConfig{}
+	}
+
 	// ExecPlugin is wrapped in single flight to exec plugin once for concurrent same image request.
 	// The caveat here is we don't know cacheKeyType yet, so if cacheKeyType is registry/global and credentials saved in cache
 	// on per registry/global basis then exec will be called for all requests if requests are made concurrently.
@@ -214,7 +242,9 @@ func (p *pluginProvider) Provide(image string) credentialprovider.DockerConfig {
 	// foo.bar.registry/image1
 	// foo.bar.registry/image2
 	res, err, _ := p.group.Do(image, func() (interface{}, error) {
-		return p.plugin.ExecPlugin(context.Background(), image)
+		response, err := p.plugin.ExecPlugin(context.Background(), image)
+		p.observeExecResult(err)
+		return response, err
 	})
 
 	if err != nil {
@@ -249,6 +279,20 @@ func (p *pluginProvider) Provide(image string) credentialprovider.DockerConfig {
 			Password: authConfig.Password,
 		}
 	}
+	// approved mirrors serve the same content as the images they mirror, so the
+	// same credentials apply. Explicitly keyed auth entries take precedence over
+	// mirror-derived ones.
+	for _, authConfig := range response.Auth {
+		for _, mirror := range authConfig.Mirrors {
+			if _, ok := dockerConfig[mirror]; ok {
+				continue
+			}
+			dockerConfig[mirror] = credentialprovider.DockerConfigEntry{
+				Username: authConfig.Username,
+				Password: authConfig.Password,
+			}
+		}
+	}
 
 	// cache duration was explicitly 0 so don't cache this response at all.
 	if response.CacheDuration != nil && response.CacheDuration.Duration == 0 {
@@ -338,6 +382,33 @@ func (p *pluginProvider) getCachedCredentials(image string) (credentialprovider.
 	return nil, false, nil
 }
 
+// skipDueToFailures returns true if the provider is in the cooldown window that
+// follows execFailureThreshold consecutive plugin failures.
+func (p *pluginProvider) skipDueToFailures() bool {
+	p.Lock()
+	defer p.Unlock()
+	return p.clock.Now().Before(p.disabledUntil)
+}
+
+// observeExecResult updates the consecutive failure count for the plugin and trips
+// the failure cooldown when the threshold is reached. A successful exec resets the count.
+func (p *pluginProvider) observeExecResult(err error) {
+	p.Lock()
+	defer p.Unlock()
+
+	if err == nil {
+		p.consecutiveFailures = 0
+		return
+	}
+
+	p.consecutiveFailures++
+	if p.consecutiveFailures >= execFailureThreshold {
+		p.disabledUntil = p.clock.Now().Add(execFailureCooldown)
+		p.consecutiveFailures = 0
+		klog.Warningf("External registry credential provider failed %d consecutive times, skipping it for %v", execFailureThreshold, execFailureCooldown)
+	}
+}
+
 // Plugin is the interface calling ExecPlugin. This is mainly for testability
 // so tests don't have to actually exec any processes.
 type Plugin interface {
@@ -354,6 +425,8 @@ type execPlugin struct {
 	args         []string
 	envVars      []kubeletconfig.ExecEnvVar
 	pluginBinDir string
+	// execTimeout bounds a single invocation of the plugin binary.
+	execTimeout time.Duration
 }
 
 // ExecPlugin executes the plugin binary with arguments and environment variables specified in CredentialProviderConfig:
@@ -375,11 +448,13 @@ func (e *execPlugin) ExecPlugin(ctx context.Context, image string) (*credentialp
 	stderr := &bytes.Buffer{}
 	stdin := bytes.NewBuffer(data)
 
-	// Use a catch-all timeout of 1 minute for all exec-based plugins, this should leave enough
-	// head room in case a plugin needs to retry a failed request while ensuring an exec plugin
-	// does not run forever. In the future we may want this timeout to be tweakable from the plugin
-	// config file.
-	ctx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+	// Bound each invocation by the per-provider timeout. In the future we may want this
+	// timeout to be tweakable from the plugin config file.
+	execTimeout := e.execTimeout
+	if execTimeout == 0 {
+		execTimeout = defaultExecTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, execTimeout)
 	defer cancel()
 
 	cmd := exec.CommandContext(ctx, filepath.Join(e.pluginBinDir, e.name), e.args...)
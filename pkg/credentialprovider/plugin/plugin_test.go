@@ -85,6 +85,36 @@ func Test_Provide(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "exact image match, with approved mirrors",
+			pluginProvider: &pluginProvider{
+				clock:          tclock,
+				lastCachePurge: tclock.Now(),
+				matchImages:    []string{"test.registry.io"},
+				cache:          cache.NewExpirationStore(cacheKeyFunc, &cacheExpirationPolicy{clock: tclock}),
+				plugin: &fakeExecPlugin{
+					cacheKeyType: credentialproviderapi.RegistryPluginCacheKeyType,
+					auth: map[string]credentialproviderapi.AuthConfig{
+						"test.registry.io": {
+							Username: "user",
+							Password: "password",
+							Mirrors:  []string{"mirror.registry.io"},
+						},
+					},
+				},
+			},
+			image: "test.registry.io/foo/bar",
+			dockerconfig: credentialprovider.DockerConfig{
+				"test.registry.io": credentialprovider.DockerConfigEntry{
+					Username: "user",
+					Password: "password",
+				},
+				"mirror.registry.io": credentialprovider.DockerConfigEntry{
+					Username: "user",
+					Password: "password",
+				},
+			},
+		},
 		{
 			name: "exact image match, with Image cache key",
 			pluginProvider: &pluginProvider{
@@ -713,3 +743,53 @@ func Test_NoCacheResponse(t *testing.T) {
 		t.Error("unexpected cache keys")
 	}
 }
+
+type failingExecPlugin struct {
+	err error
+}
+
+func (f *failingExecPlugin) ExecPlugin(ctx context.Context, image string) (*credentialproviderapi.CredentialProviderResponse, error) {
+	return nil, f.err
+}
+
+func Test_ProviderFailureCooldown(t *testing.T) {
+	fakeClock := clock.NewFakeClock(time.Now())
+	pluginProvider := &pluginProvider{
+		clock:          fakeClock,
+		lastCachePurge: fakeClock.Now(),
+		matchImages:    []string{"test.registry.io"},
+		cache:          cache.NewExpirationStore(cacheKeyFunc, &cacheExpirationPolicy{clock: fakeClock}),
+		plugin:         &failingExecPlugin{err: fmt.Errorf("exec failure")},
+	}
+
+	// failures below the threshold should not trip the cooldown
+	for i := 0; i < execFailureThreshold-1; i++ {
+		pluginProvider.Provide(fmt.Sprintf("test.registry.io/foo%d", i))
+	}
+	if pluginProvider.skipDueToFailures() {
+		t.Fatalf("expected provider to still be invoked below the failure threshold")
+	}
+
+	// one more failure trips the cooldown
+	pluginProvider.Provide("test.registry.io/last")
+	if !pluginProvider.skipDueToFailures() {
+		t.Fatalf("expected provider to be in cooldown after %d consecutive failures", execFailureThreshold)
+	}
+
+	// once the cooldown expires, the plugin is invoked again
+	fakeClock.Step(execFailureCooldown + time.Second)
+	if pluginProvider.skipDueToFailures() {
+		t.Fatalf("expected provider cooldown to expire after %v", execFailureCooldown)
+	}
+
+	// a success resets the consecutive failure count
+	pluginProvider.plugin = &fakeExecPlugin{cacheKeyType: credentialproviderapi.RegistryPluginCacheKeyType}
+	pluginProvider.Provide("test.registry.io/reset")
+	pluginProvider.plugin = &failingExecPlugin{err: fmt.Errorf("exec failure")}
+	for i := 0; i < execFailureThreshold-1; i++ {
+		pluginProvider.Provide(fmt.Sprintf("test.registry.io/bar%d", i))
+	}
+	if pluginProvider.skipDueToFailures() {
+		t.Fatalf("expected failure count to reset after a successful exec")
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apiserver/pkg/authorization/authorizer"
 	"k8s.io/apiserver/pkg/authorization/authorizerfactory"
+	authorizationmetrics "k8s.io/apiserver/pkg/authorization/metrics"
 	"k8s.io/apiserver/pkg/authorization/union"
 	"k8s.io/apiserver/plugin/pkg/authorizer/webhook"
 	versionedinformers "k8s.io/client-go/informers"
@@ -92,23 +93,23 @@ func (config Config) New() (authorizer.Authorizer, authorizer.RuleResolver, erro
 				config.VersionedInformerFactory.Storage().V1().VolumeAttachments(),
 			)
 			nodeAuthorizer := node.NewAuthorizer(graph, nodeidentifier.NewDefaultNodeIdentifier(), bootstrappolicy.NodeRules())
-			authorizers = append(authorizers, nodeAuthorizer)
+			authorizers = append(authorizers, authorizationmetrics.NewInstrumentedAuthorizer(authorizationMode, nodeAuthorizer))
 			ruleResolvers = append(ruleResolvers, nodeAuthorizer)
 
 		case modes.ModeAlwaysAllow:
 			alwaysAllowAuthorizer := authorizerfactory.NewAlwaysAllowAuthorizer()
-			authorizers = append(authorizers, alwaysAllowAuthorizer)
+			authorizers = append(authorizers, authorizationmetrics.NewInstrumentedAuthorizer(authorizationMode, alwaysAllowAuthorizer))
 			ruleResolvers = append(ruleResolvers, alwaysAllowAuthorizer)
 		case modes.ModeAlwaysDeny:
 			alwaysDenyAuthorizer := authorizerfactory.NewAlwaysDenyAuthorizer()
-			authorizers = append(authorizers, alwaysDenyAuthorizer)
+			authorizers = append(authorizers, authorizationmetrics.NewInstrumentedAuthorizer(authorizationMode, alwaysDenyAuthorizer))
 			ruleResolvers = append(ruleResolvers, alwaysDenyAuthorizer)
 		case modes.ModeABAC:
 			abacAuthorizer, err := abac.NewFromFile(config.PolicyFile)
 			if err != nil {
 				return nil, nil, err
 			}
-			authorizers = append(authorizers, abacAuthorizer)
+			authorizers = append(authorizers, authorizationmetrics.NewInstrumentedAuthorizer(authorizationMode, abacAuthorizer))
 			ruleResolvers = append(ruleResolvers, abacAuthorizer)
 		case modes.ModeWebhook:
 			if config.WebhookRetryBackoff == nil {
@@ -123,7 +124,7 @@ func (config Config) New() (authorizer.Authorizer, authorizer.RuleResolver, erro
 			if err != nil {
 				return nil, nil, err
 			}
-			authorizers = append(authorizers, webhookAuthorizer)
+			authorizers = append(authorizers, authorizationmetrics.NewInstrumentedAuthorizer(authorizationMode, webhookAuthorizer))
 			ruleResolvers = append(ruleResolvers, webhookAuthorizer)
 		case modes.ModeRBAC:
 			rbacAuthorizer := rbac.New(
@@ -132,7 +133,7 @@ func (config Config) New() (authorizer.Authorizer, authorizer.RuleResolver, erro
 				&rbac.ClusterRoleGetter{Lister: config.VersionedInformerFactory.Rbac().V1().ClusterRoles().Lister()},
 				&rbac.ClusterRoleBindingLister{Lister: config.VersionedInformerFactory.Rbac().V1().ClusterRoleBindings().Lister()},
 			)
-			authorizers = append(authorizers, rbacAuthorizer)
+			authorizers = append(authorizers, authorizationmetrics.NewInstrumentedAuthorizer(authorizationMode, rbacAuthorizer))
 			ruleResolvers = append(ruleResolvers, rbacAuthorizer)
 		default:
 			return nil, nil, fmt.Errorf("unknown authorization mode %s specified", authorizationMode)
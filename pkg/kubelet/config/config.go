@@ -28,6 +28,7 @@ import (
 	"k8s.io/klog/v2"
 	kubecontainer "k8s.io/kubernetes/pkg/kubelet/container"
 	"k8s.io/kubernetes/pkg/kubelet/events"
+	"k8s.io/kubernetes/pkg/kubelet/metrics"
 	kubetypes "k8s.io/kubernetes/pkg/kubelet/types"
 	"k8s.io/kubernetes/pkg/kubelet/util/format"
 	"k8s.io/kubernetes/pkg/util/config"
@@ -108,6 +109,14 @@ func (c *PodConfig) Sync() {
 	c.pods.Sync()
 }
 
+// SetSourcePrecedence configures the order (highest precedence first) in
+// which sources win when the same pod name arrives from multiple sources.
+// Sources not listed rank below all listed sources. It should be called
+// before sources start delivering updates.
+func (c *PodConfig) SetSourcePrecedence(sources ...string) {
+	c.pods.setSourcePrecedence(sources)
+}
+
 // podStorage manages the current pod state at any point in time and ensures updates
 // to the channel are delivered in order.  Note that this object is an in-memory source of
 // "truth" and on creation contains zero entries.  Once all previously read sources are
@@ -127,20 +136,31 @@ type podStorage struct {
 	sourcesSeenLock sync.RWMutex
 	sourcesSeen     sets.String
 
+	// sourcePrecedence orders sources, highest precedence first, for
+	// resolving pods with the same name arriving from multiple sources.
+	// Guarded by podLock.
+	sourcePrecedence []string
+
 	// the EventRecorder to use
 	recorder record.EventRecorder
 }
 
+// defaultSourcePrecedence orders the sources a pod may arrive from, highest
+// precedence first: a static pod defined on disk wins over the same name from
+// an HTTP source, which wins over the apiserver.
+var defaultSourcePrecedence = []string{kubetypes.FileSource, kubetypes.HTTPSource, kubetypes.ApiserverSource}
+
 // TODO: PodConfigNotificationMode could be handled by a listener to the updates channel
 // in the future, especially with multiple listeners.
 // TODO: allow initialization of the current state of the store with snapshotted version.
 func newPodStorage(updates chan<- kubetypes.PodUpdate, mode PodConfigNotificationMode, recorder record.EventRecorder) *podStorage {
 	return &podStorage{
-		pods:        make(map[string]map[types.UID]*v1.Pod),
-		mode:        mode,
-		updates:     updates,
-		sourcesSeen: sets.String{},
-		recorder:    recorder,
+		pods:             make(map[string]map[types.UID]*v1.Pod),
+		mode:             mode,
+		updates:          updates,
+		sourcesSeen:      sets.String{},
+		sourcePrecedence: defaultSourcePrecedence,
+		recorder:         recorder,
 	}
 }
 
@@ -244,6 +264,25 @@ func (s *podStorage) merge(source string, change interface{}) (adds, updates, de
 				}
 				continue
 			}
+			// Mirror pods legitimately share their name with a static pod
+			// from another source and are excluded from conflict resolution.
+			if !kubetypes.IsMirrorPod(ref) {
+				if otherSource, otherPod := s.conflictingPod(source, ref); otherPod != nil {
+					if !s.sourcePrecedes(source, otherSource) {
+						metrics.PodSourceConflicts.WithLabelValues(source, otherSource).Inc()
+						klog.V(2).InfoS("Ignoring pod, a pod with the same name is already provided by a source with higher precedence", "pod", klog.KObj(ref), "source", source, "winningSource", otherSource)
+						s.recorder.Eventf(ref, v1.EventTypeWarning, events.PodSourceConflict, "Pod %s from %s ignored, already provided by source %s", format.Pod(ref), source, otherSource)
+						continue
+					}
+					// Evict the already accepted lower-precedence pod so the
+					// outcome does not depend on which source delivered first.
+					delete(s.pods[otherSource], otherPod.UID)
+					removePods = append(removePods, otherPod)
+					metrics.PodSourceConflicts.WithLabelValues(otherSource, source).Inc()
+					klog.V(2).InfoS("Pod name is provided by multiple sources, this source takes precedence", "pod", klog.KObj(ref), "source", source, "losingSource", otherSource)
+					s.recorder.Eventf(ref, v1.EventTypeWarning, events.PodSourceConflict, "Pod %s provided by both %s and %s, %s takes precedence and the copy from %s is removed", format.Pod(ref), source, otherSource, source, otherSource)
+				}
+			}
 			recordFirstSeenTime(ref)
 			pods[ref.UID] = ref
 			addPods = append(addPods, ref)
@@ -304,6 +343,45 @@ func (s *podStorage) merge(source string, change interface{}) (adds, updates, de
 	return adds, updates, deletes, removes, reconciles
 }
 
+func (s *podStorage) setSourcePrecedence(sources []string) {
+	s.podLock.Lock()
+	defer s.podLock.Unlock()
+	s.sourcePrecedence = sources
+}
+
+// sourcePrecedes returns true if source a has higher precedence than source b.
+// Sources not in the precedence list rank below all listed sources.
+// The caller must hold podLock.
+func (s *podStorage) sourcePrecedes(a, b string) bool {
+	for _, src := range s.sourcePrecedence {
+		switch src {
+		case a:
+			return true
+		case b:
+			return false
+		}
+	}
+	return false
+}
+
+// conflictingPod returns another source that already provides a pod with the
+// same full name as ref, along with that pod, or "", nil if there is none.
+// The caller must hold podLock.
+func (s *podStorage) conflictingPod(source string, ref *v1.Pod) (string, *v1.Pod) {
+	name := kubecontainer.GetPodFullName(ref)
+	for otherSource, pods := range s.pods {
+		if otherSource == source {
+			continue
+		}
+		for _, existing := range pods {
+			if kubecontainer.GetPodFullName(existing) == name {
+				return otherSource, existing
+			}
+		}
+	}
+	return "", nil
+}
+
 func (s *podStorage) markSourceSet(source string) {
 	s.sourcesSeenLock.Lock()
 	defer s.sourcesSeenLock.Unlock()
@@ -413,3 +413,67 @@ func TestPodUpdateLabels(t *testing.T) {
 	expectPodUpdate(t, ch, CreatePodUpdate(kubetypes.UPDATE, TestSource, pod))
 
 }
+
+func TestPodSourcePrecedence(t *testing.T) {
+	eventBroadcaster := record.NewBroadcaster()
+	config := NewPodConfig(PodConfigNotificationIncremental, eventBroadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: "kubelet"}))
+	fileCh := config.Channel(kubetypes.FileSource)
+	httpCh := config.Channel(kubetypes.HTTPSource)
+	ch := config.Updates()
+
+	filePod := CreateValidPod("static", "new")
+	filePod.UID = "file-uid"
+	fileCh <- CreatePodUpdate(kubetypes.ADD, kubetypes.FileSource, filePod)
+	expectPodUpdate(t, ch, CreatePodUpdate(kubetypes.ADD, kubetypes.FileSource, CreateValidPod("static", "new")))
+
+	// the same pod name from the lower-precedence http source is ignored
+	httpPod := CreateValidPod("static", "new")
+	httpPod.UID = "http-uid"
+	httpCh <- CreatePodUpdate(kubetypes.ADD, kubetypes.HTTPSource, httpPod)
+	expectNoPodUpdate(t, ch)
+
+	// an unrelated pod from the http source is still accepted
+	httpCh <- CreatePodUpdate(kubetypes.ADD, kubetypes.HTTPSource, CreateValidPod("other", "new"))
+	expectPodUpdate(t, ch, CreatePodUpdate(kubetypes.ADD, kubetypes.HTTPSource, CreateValidPod("other", "new")))
+
+	// with inverted precedence, the file pod loses instead
+	config = NewPodConfig(PodConfigNotificationIncremental, eventBroadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: "kubelet"}))
+	config.SetSourcePrecedence(kubetypes.HTTPSource, kubetypes.FileSource)
+	fileCh = config.Channel(kubetypes.FileSource)
+	httpCh = config.Channel(kubetypes.HTTPSource)
+	ch = config.Updates()
+
+	httpPod = CreateValidPod("static", "new")
+	httpPod.UID = "http-uid"
+	httpCh <- CreatePodUpdate(kubetypes.ADD, kubetypes.HTTPSource, httpPod)
+	expectPodUpdate(t, ch, CreatePodUpdate(kubetypes.ADD, kubetypes.HTTPSource, CreateValidPod("static", "new")))
+
+	filePod = CreateValidPod("static", "new")
+	filePod.UID = "file-uid"
+	fileCh <- CreatePodUpdate(kubetypes.ADD, kubetypes.FileSource, filePod)
+	expectNoPodUpdate(t, ch)
+}
+
+func TestPodSourcePrecedenceWinnerArrivesSecond(t *testing.T) {
+	eventBroadcaster := record.NewBroadcaster()
+	config := NewPodConfig(PodConfigNotificationIncremental, eventBroadcaster.NewRecorder(scheme.Scheme, v1.EventSource{Component: "kubelet"}))
+	fileCh := config.Channel(kubetypes.FileSource)
+	httpCh := config.Channel(kubetypes.HTTPSource)
+	ch := config.Updates()
+
+	// the lower-precedence http source delivers the pod first and is accepted
+	httpPod := CreateValidPod("static", "new")
+	httpPod.UID = "http-uid"
+	httpCh <- CreatePodUpdate(kubetypes.ADD, kubetypes.HTTPSource, httpPod)
+	expectPodUpdate(t, ch, CreatePodUpdate(kubetypes.ADD, kubetypes.HTTPSource, CreateValidPod("static", "new")))
+
+	// when the same name later arrives from the higher-precedence file
+	// source, the http copy is evicted before the file pod is added, so the
+	// winner does not depend on delivery order
+	filePod := CreateValidPod("static", "new")
+	filePod.UID = "file-uid"
+	fileCh <- CreatePodUpdate(kubetypes.ADD, kubetypes.FileSource, filePod)
+	expectPodUpdate(t, ch,
+		CreatePodUpdate(kubetypes.REMOVE, kubetypes.FileSource, CreateValidPod("static", "new")),
+		CreatePodUpdate(kubetypes.ADD, kubetypes.FileSource, CreateValidPod("static", "new")))
+}
@@ -28,7 +28,11 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
 	api "k8s.io/kubernetes/pkg/apis/core"
 	kubetypes "k8s.io/kubernetes/pkg/kubelet/types"
 	utilio "k8s.io/utils/io"
@@ -42,6 +46,11 @@ const (
 	podDelete
 
 	eventBufferLen = 10
+
+	// podPatchSuffix is the extension of optional node-local strategic merge
+	// patch files applied to the manifest they sit next to (e.g. etcd.yaml.patch
+	// overrides etcd.yaml), enabling per-node overrides of shared manifests.
+	podPatchSuffix = ".patch"
 )
 
 type watchEvent struct {
@@ -57,14 +66,23 @@ type sourceFile struct {
 	fileKeyMapping map[string]string
 	updates        chan<- interface{}
 	watchEvents    chan *watchEvent
+	failures       *podSourceFailureTracker
 }
 
 // NewSourceFile watches a config file for changes.
 func NewSourceFile(path string, nodeName types.NodeName, period time.Duration, updates chan<- interface{}) {
+	NewSourceFileWithEvents(path, nodeName, period, updates, nil, nil)
+}
+
+// NewSourceFileWithEvents is like NewSourceFile, but additionally reports
+// manifest decode and validation failures as warning events on the node, so
+// rejected static pods can be diagnosed without access to the kubelet log.
+func NewSourceFileWithEvents(path string, nodeName types.NodeName, period time.Duration, updates chan<- interface{}, recorder record.EventRecorder, nodeRef *v1.ObjectReference) {
 	// "github.com/sigma/go-inotify" requires a path without trailing "/"
 	path = strings.TrimRight(path, string(os.PathSeparator))
 
 	config := newSourceFile(path, nodeName, period, updates)
+	config.failures = newPodSourceFailureTracker(recorder, nodeRef)
 	klog.V(1).InfoS("Watching path", "path", path)
 	config.run()
 }
@@ -86,6 +104,7 @@ func newSourceFile(path string, nodeName types.NodeName, period time.Duration, u
 		fileKeyMapping: map[string]string{},
 		updates:        updates,
 		watchEvents:    make(chan *watchEvent, eventBufferLen),
+		failures:       newPodSourceFailureTracker(nil, nil),
 	}
 }
 
@@ -163,6 +182,8 @@ func (s *sourceFile) extractFromDir(name string) ([]*v1.Pod, error) {
 	if err != nil {
 		return nil, fmt.Errorf("glob failed: %v", err)
 	}
+	// forget failures for manifests that no longer exist
+	defer s.failures.retain(sets.NewString(dirents...))
 
 	pods := make([]*v1.Pod, 0, len(dirents))
 	if len(dirents) == 0 {
@@ -178,6 +199,8 @@ func (s *sourceFile) extractFromDir(name string) ([]*v1.Pod, error) {
 		}
 
 		switch {
+		case strings.HasSuffix(path, podPatchSuffix):
+			// patch files are applied when the manifest they belong to is read
 		case statInfo.Mode().IsDir():
 			klog.ErrorS(nil, "Provided manifest path is a directory, not recursing into manifest path", "path", path)
 		case statInfo.Mode().IsRegular():
@@ -204,9 +227,15 @@ func (s *sourceFile) extractFromFile(filename string) (pod *v1.Pod, err error) {
 			objKey, keyErr := cache.MetaNamespaceKeyFunc(pod)
 			if keyErr != nil {
 				err = keyErr
+				s.failures.record(filename, err)
 				return
 			}
 			s.fileKeyMapping[filename] = objKey
+			s.failures.clear(filename)
+			return
+		}
+		if err != nil && !os.IsNotExist(err) {
+			s.failures.record(filename, err)
 		}
 	}()
 
@@ -221,6 +250,11 @@ func (s *sourceFile) extractFromFile(filename string) (pod *v1.Pod, err error) {
 		return pod, err
 	}
 
+	data, err = s.applyLocalPatch(filename, data)
+	if err != nil {
+		return pod, err
+	}
+
 	defaultFn := func(pod *api.Pod) error {
 		return s.applyDefaults(pod, filename)
 	}
@@ -236,6 +270,39 @@ func (s *sourceFile) extractFromFile(filename string) (pod *v1.Pod, err error) {
 	return pod, fmt.Errorf("%v: couldn't parse as pod(%v), please check config file", filename, podErr)
 }
 
+// applyLocalPatch applies the node-local strategic merge patch next to the
+// manifest, if one exists, to the manifest bytes before they are decoded, so
+// defaulting and validation run against the patched pod.
+func (s *sourceFile) applyLocalPatch(filename string, data []byte) ([]byte, error) {
+	patchFile, err := os.Open(filename + podPatchSuffix)
+	if os.IsNotExist(err) {
+		return data, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer patchFile.Close()
+
+	patchData, err := utilio.ReadAtMost(patchFile, maxConfigLength)
+	if err != nil {
+		return nil, err
+	}
+	originalJSON, err := utilyaml.ToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid manifest: %v", err)
+	}
+	patchJSON, err := utilyaml.ToJSON(patchData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid patch %s: %v", filename+podPatchSuffix, err)
+	}
+	patched, err := strategicpatch.StrategicMergePatch(originalJSON, patchJSON, &v1.Pod{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply patch %s: %v", filename+podPatchSuffix, err)
+	}
+	klog.V(3).InfoS("Applied local patch to manifest", "path", filename, "patchPath", filename+podPatchSuffix)
+	return patched, nil
+}
+
 func (s *sourceFile) replaceStore(pods ...*v1.Pod) (err error) {
 	objs := []interface{}{}
 	for _, pod := range pods {
@@ -127,6 +127,15 @@ func (s *sourceFile) produceWatchEvent(e *fsnotify.Event) error {
 }
 
 func (s *sourceFile) consumeWatchEvent(e *watchEvent) error {
+	if strings.HasSuffix(e.fileName, podPatchSuffix) {
+		// Changes to a patch file are handled by re-reading the manifest it
+		// applies to, if that manifest exists.
+		manifest := strings.TrimSuffix(e.fileName, podPatchSuffix)
+		if _, err := os.Stat(manifest); err != nil {
+			return nil
+		}
+		e = &watchEvent{fileName: manifest, eventType: podModify}
+	}
 	switch e.eventType {
 	case podAdd, podModify:
 		pod, err := s.extractFromFile(e.fileName)
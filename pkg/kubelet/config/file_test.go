@@ -20,10 +20,14 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
+	v1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/kubernetes/pkg/kubelet/events"
 	kubetypes "k8s.io/kubernetes/pkg/kubelet/types"
 )
 
@@ -49,6 +53,149 @@ func TestExtractFromBadDataFile(t *testing.T) {
 	expectEmptyChannel(t, ch)
 }
 
+func TestExtractFromBadDataFileTracksFailure(t *testing.T) {
+	dirName, err := mkTempDir("file-test")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer removeAll(dirName, t)
+
+	fileName := filepath.Join(dirName, "test_pod_config")
+	err = ioutil.WriteFile(fileName, []byte{1, 2, 3}, 0555)
+	if err != nil {
+		t.Fatalf("unable to write test file %#v", err)
+	}
+
+	ch := make(chan interface{}, 10)
+	lw := newSourceFile(dirName, "localhost", time.Millisecond, ch)
+	recorder := record.NewFakeRecorder(1)
+	lw.failures = newPodSourceFailureTracker(recorder, &v1.ObjectReference{Kind: "Node", Name: "localhost"})
+
+	if err := lw.listConfig(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	failures := lw.failures.current()
+	if len(failures) != 1 || failures[0].path != fileName {
+		t.Fatalf("expected a recorded failure for %s, got %#v", fileName, failures)
+	}
+	select {
+	case event := <-recorder.Events:
+		if !strings.Contains(event, events.FailedStaticPodDecode) {
+			t.Errorf("expected %s event, got %q", events.FailedStaticPodDecode, event)
+		}
+	default:
+		t.Errorf("expected a node event to be emitted")
+	}
+
+	// the same failure on relist must not emit another event
+	if err := lw.listConfig(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	select {
+	case event := <-recorder.Events:
+		t.Errorf("expected no additional event, got %q", event)
+	default:
+	}
+
+	// removing the file clears the failure
+	removeAll(fileName, t)
+	if err := lw.listConfig(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if failures := lw.failures.current(); len(failures) != 0 {
+		t.Errorf("expected no recorded failures after removal, got %#v", failures)
+	}
+}
+
+func TestExtractFromFileWithLocalPatch(t *testing.T) {
+	dirName, err := mkTempDir("file-test")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer removeAll(dirName, t)
+
+	manifest := `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: static-web
+spec:
+  containers:
+  - name: web
+    image: nginx:1
+`
+	patch := `
+metadata:
+  annotations:
+    patched: "true"
+spec:
+  containers:
+  - name: web
+    image: nginx:2
+`
+	fileName := filepath.Join(dirName, "static-web.yaml")
+	if err := ioutil.WriteFile(fileName, []byte(manifest), 0644); err != nil {
+		t.Fatalf("unable to write test file: %v", err)
+	}
+	if err := ioutil.WriteFile(fileName+podPatchSuffix, []byte(patch), 0644); err != nil {
+		t.Fatalf("unable to write patch file: %v", err)
+	}
+
+	ch := make(chan interface{}, 1)
+	lw := newSourceFile(dirName, "localhost", time.Millisecond, ch)
+	pod, err := lw.extractFromFile(fileName)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pod.Spec.Containers) != 1 || pod.Spec.Containers[0].Image != "nginx:2" {
+		t.Errorf("expected patched image nginx:2, got %#v", pod.Spec.Containers)
+	}
+	if pod.Annotations["patched"] != "true" {
+		t.Errorf("expected patched annotation, got %v", pod.Annotations)
+	}
+
+	// the patch file itself must not be treated as a manifest
+	pods, err := lw.extractFromDir(dirName)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pods) != 1 {
+		t.Errorf("expected a single pod from the directory, got %d", len(pods))
+	}
+}
+
+func TestExtractFromFileWithInvalidPatch(t *testing.T) {
+	dirName, err := mkTempDir("file-test")
+	if err != nil {
+		t.Fatalf("unable to create temp dir: %v", err)
+	}
+	defer removeAll(dirName, t)
+
+	manifest := `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: static-web
+spec:
+  containers:
+  - name: web
+    image: nginx:1
+`
+	fileName := filepath.Join(dirName, "static-web.yaml")
+	if err := ioutil.WriteFile(fileName, []byte(manifest), 0644); err != nil {
+		t.Fatalf("unable to write test file: %v", err)
+	}
+	if err := ioutil.WriteFile(fileName+podPatchSuffix, []byte("{not yaml"), 0644); err != nil {
+		t.Fatalf("unable to write patch file: %v", err)
+	}
+
+	ch := make(chan interface{}, 1)
+	lw := newSourceFile(dirName, "localhost", time.Millisecond, ch)
+	if _, err := lw.extractFromFile(fileName); err == nil {
+		t.Fatalf("expected error from invalid patch, got nil")
+	}
+}
+
 func TestExtractFromEmptyDir(t *testing.T) {
 	dirName, err := mkTempDir("file-test")
 	if err != nil {
@@ -23,8 +23,10 @@ import (
 	"time"
 
 	v1 "k8s.io/api/core/v1"
+	utilnet "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/apimachinery/pkg/util/wait"
 	api "k8s.io/kubernetes/pkg/apis/core"
+	"k8s.io/kubernetes/pkg/kubelet/metrics"
 	kubetypes "k8s.io/kubernetes/pkg/kubelet/types"
 
 	"k8s.io/apimachinery/pkg/types"
@@ -40,6 +42,12 @@ type sourceURL struct {
 	data        []byte
 	failureLogs int
 	client      *http.Client
+
+	// Validators from the last successful response, sent as conditional request
+	// headers so an unchanged manifest is answered with 304 Not Modified instead
+	// of being re-downloaded and re-parsed on every sync.
+	etag         string
+	lastModified string
 }
 
 // NewSourceURL specifies the URL where to read the Pod configuration from, then watches it for changes.
@@ -70,6 +78,7 @@ func (s *sourceURL) run() {
 			klog.V(4).InfoS("Failed to read pods from URL", "err", err)
 		}
 		s.failureLogs++
+		metrics.HTTPSourceFetchFailures.Inc()
 	} else {
 		if s.failureLogs > 0 {
 			klog.InfoS("Successfully read pods from URL")
@@ -87,12 +96,22 @@ func (s *sourceURL) extractFromURL() error {
 	if err != nil {
 		return err
 	}
-	req.Header = s.header
+	req.Header = utilnet.CloneHeader(s.header)
+	if len(s.etag) > 0 {
+		req.Header.Set("If-None-Match", s.etag)
+	}
+	if len(s.lastModified) > 0 {
+		req.Header.Set("If-Modified-Since", s.lastModified)
+	}
 	resp, err := s.client.Do(req)
 	if err != nil {
 		return err
 	}
 	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified {
+		// The manifest has not changed since the last successful read.
+		return nil
+	}
 	data, err := utilio.ReadAtMost(resp.Body, maxConfigLength)
 	if err != nil {
 		return err
@@ -105,6 +124,8 @@ func (s *sourceURL) extractFromURL() error {
 		s.updates <- kubetypes.PodUpdate{Pods: []*v1.Pod{}, Op: kubetypes.SET, Source: kubetypes.HTTPSource}
 		return fmt.Errorf("zero-length data received from %v", s.url)
 	}
+	s.etag = resp.Header.Get("Etag")
+	s.lastModified = resp.Header.Get("Last-Modified")
 	// Short circuit if the data has not changed since the last time it was read.
 	if bytes.Equal(data, s.data) {
 		return nil
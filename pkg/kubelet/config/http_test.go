@@ -48,7 +48,7 @@ func TestURLErrorNotExistNoUpdate(t *testing.T) {
 
 func TestExtractFromHttpBadness(t *testing.T) {
 	ch := make(chan interface{}, 1)
-	c := sourceURL{"http://localhost:49575/_not_found_", http.Header{}, "other", ch, nil, 0, http.DefaultClient}
+	c := sourceURL{url: "http://localhost:49575/_not_found_", header: http.Header{}, nodeName: "other", updates: ch, client: http.DefaultClient}
 	if err := c.extractFromURL(); err == nil {
 		t.Errorf("Expected error")
 	}
@@ -117,7 +117,7 @@ func TestExtractInvalidPods(t *testing.T) {
 		testServer := httptest.NewServer(&fakeHandler)
 		defer testServer.Close()
 		ch := make(chan interface{}, 1)
-		c := sourceURL{testServer.URL, http.Header{}, "localhost", ch, nil, 0, http.DefaultClient}
+		c := sourceURL{url: testServer.URL, header: http.Header{}, nodeName: "localhost", updates: ch, client: http.DefaultClient}
 		if err := c.extractFromURL(); err == nil {
 			t.Errorf("%s: Expected error", testCase.desc)
 		}
@@ -303,7 +303,7 @@ func TestExtractPodsFromHTTP(t *testing.T) {
 		testServer := httptest.NewServer(&fakeHandler)
 		defer testServer.Close()
 		ch := make(chan interface{}, 1)
-		c := sourceURL{testServer.URL, http.Header{}, types.NodeName(nodeName), ch, nil, 0, http.DefaultClient}
+		c := sourceURL{url: testServer.URL, header: http.Header{}, nodeName: types.NodeName(nodeName), updates: ch, client: http.DefaultClient}
 		if err := c.extractFromURL(); err != nil {
 			t.Errorf("%s: Unexpected error: %v", testCase.desc, err)
 			continue
@@ -326,6 +326,70 @@ func TestExtractPodsFromHTTP(t *testing.T) {
 	}
 }
 
+func TestURLConditionalRequests(t *testing.T) {
+	pod := &v1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Pod",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			UID:       "111",
+			Namespace: "mynamespace",
+		},
+		Spec: v1.PodSpec{
+			NodeName:   "localhost",
+			Containers: []v1.Container{{Name: "1", Image: "foo", ImagePullPolicy: v1.PullAlways}},
+		},
+	}
+	data, err := json.Marshal(pod)
+	if err != nil {
+		t.Fatalf("Unexpected json marshalling error: %v", err)
+	}
+
+	const etag = `"v1"`
+	requests := 0
+	notModified := 0
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == etag {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Etag", etag)
+		w.Write(data)
+	}))
+	defer testServer.Close()
+
+	ch := make(chan interface{}, 1)
+	c := sourceURL{url: testServer.URL, header: http.Header{}, nodeName: "localhost", updates: ch, client: http.DefaultClient}
+	if err := c.extractFromURL(); err != nil {
+		t.Fatalf("Unexpected error extracting from URL: %v", err)
+	}
+	update := (<-ch).(kubetypes.PodUpdate)
+	if len(update.Pods) != 1 {
+		t.Errorf("Received wrong number of pods, expected one: %v", update.Pods)
+	}
+	if c.etag != etag {
+		t.Errorf("expected recorded etag %q, got %q", etag, c.etag)
+	}
+
+	// an unchanged manifest must be answered from the conditional request
+	// without producing another update
+	if err := c.extractFromURL(); err != nil {
+		t.Fatalf("Unexpected error extracting from URL: %v", err)
+	}
+	if requests != 2 || notModified != 1 {
+		t.Errorf("expected 2 requests with 1 not-modified response, got %d/%d", requests, notModified)
+	}
+	select {
+	case got := <-ch:
+		t.Errorf("expected no update for unchanged manifest, got %#v", got)
+	default:
+	}
+}
+
 func TestURLWithHeader(t *testing.T) {
 	pod := &v1.Pod{
 		TypeMeta: metav1.TypeMeta{
@@ -355,7 +419,7 @@ func TestURLWithHeader(t *testing.T) {
 	ch := make(chan interface{}, 1)
 	header := make(http.Header)
 	header.Set("Metadata-Flavor", "Google")
-	c := sourceURL{testServer.URL, header, "localhost", ch, nil, 0, http.DefaultClient}
+	c := sourceURL{url: testServer.URL, header: header, nodeName: "localhost", updates: ch, client: http.DefaultClient}
 	if err := c.extractFromURL(); err != nil {
 		t.Fatalf("Unexpected error extracting from URL: %v", err)
 	}
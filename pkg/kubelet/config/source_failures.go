@@ -0,0 +1,98 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/kubernetes/pkg/kubelet/events"
+)
+
+// podSourceFailure records the most recent failure to turn a manifest into a pod.
+type podSourceFailure struct {
+	path      string
+	err       string
+	timestamp time.Time
+}
+
+// podSourceFailureTracker keeps the latest decode or validation failure per
+// manifest path and reports each new failure as an event on the node, so bad
+// static pod manifests can be diagnosed without access to the kubelet log.
+type podSourceFailureTracker struct {
+	lock     sync.Mutex
+	recorder record.EventRecorder
+	nodeRef  *v1.ObjectReference
+	failures map[string]podSourceFailure
+}
+
+func newPodSourceFailureTracker(recorder record.EventRecorder, nodeRef *v1.ObjectReference) *podSourceFailureTracker {
+	return &podSourceFailureTracker{
+		recorder: recorder,
+		nodeRef:  nodeRef,
+		failures: map[string]podSourceFailure{},
+	}
+}
+
+// record notes that the manifest at path could not be processed. An event is
+// emitted when a path starts failing or its failure changes, but not on every
+// relist, to avoid flooding the node with identical events.
+func (t *podSourceFailureTracker) record(path string, err error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	previous, found := t.failures[path]
+	t.failures[path] = podSourceFailure{path: path, err: err.Error(), timestamp: time.Now()}
+	if found && previous.err == err.Error() {
+		return
+	}
+	if t.recorder != nil && t.nodeRef != nil {
+		t.recorder.Eventf(t.nodeRef, v1.EventTypeWarning, events.FailedStaticPodDecode, "Static pod manifest %s rejected: %v", path, err)
+	}
+}
+
+// clear forgets any recorded failure for the manifest at path.
+func (t *podSourceFailureTracker) clear(path string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	delete(t.failures, path)
+}
+
+// retain drops recorded failures for paths no longer present in the source,
+// e.g. manifests deleted from a watched directory.
+func (t *podSourceFailureTracker) retain(paths sets.String) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for path := range t.failures {
+		if !paths.Has(path) {
+			delete(t.failures, path)
+		}
+	}
+}
+
+// current returns a snapshot of the paths that are currently failing.
+func (t *podSourceFailureTracker) current() []podSourceFailure {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	failures := make([]podSourceFailure, 0, len(t.failures))
+	for _, failure := range t.failures {
+		failures = append(failures, failure)
+	}
+	return failures
+}
@@ -33,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apiserver/pkg/util/wsstream"
 	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/flowcontrol"
 
 	"k8s.io/klog/v2"
 )
@@ -409,16 +410,38 @@ WaitForStreams:
 // supportsTerminalResizing returns false because v1ProtocolHandler doesn't support it.
 func (*v1ProtocolHandler) supportsTerminalResizing() bool { return false }
 
+const (
+	// maxResizeDimension bounds the terminal dimensions accepted from a resize
+	// event. The resize stream is client-controlled input; larger values do
+	// not come from a real terminal and are dropped.
+	maxResizeDimension = 4096
+	// resizeEventQPS and resizeEventBurst bound the sustained rate of resize
+	// events per connection, well above what interactive window resizing
+	// produces. A stream exceeding the limit is abandoned to protect the
+	// kubelet from resize floods.
+	resizeEventQPS   = 10
+	resizeEventBurst = 64
+)
+
 func handleResizeEvents(stream io.Reader, channel chan<- remotecommand.TerminalSize) {
 	defer runtime.HandleCrash()
 	defer close(channel)
 
+	limiter := flowcontrol.NewTokenBucketRateLimiter(resizeEventQPS, resizeEventBurst)
 	decoder := json.NewDecoder(stream)
 	for {
 		size := remotecommand.TerminalSize{}
 		if err := decoder.Decode(&size); err != nil {
 			break
 		}
+		if size.Width > maxResizeDimension || size.Height > maxResizeDimension {
+			runtime.HandleError(fmt.Errorf("ignoring invalid terminal size from resize stream: %+v", size))
+			continue
+		}
+		if !limiter.TryAccept() {
+			runtime.HandleError(errors.New("terminal resize events exceeded rate limit, stopping resize handling for this connection"))
+			return
+		}
 		channel <- size
 	}
 }
@@ -33,6 +33,7 @@ import (
 
 	"k8s.io/apimachinery/pkg/types"
 	remotecommandconsts "k8s.io/apimachinery/pkg/util/remotecommand"
+	"k8s.io/apiserver/pkg/util/wsstream"
 	"k8s.io/client-go/tools/remotecommand"
 	runtimeapi "k8s.io/cri-api/pkg/apis/runtime/v1alpha2"
 	"k8s.io/kubernetes/pkg/kubelet/cri/streaming/portforward"
@@ -92,6 +93,12 @@ type Config struct {
 
 	// The config for serving over TLS. If nil, TLS will not be used.
 	TLSConfig *tls.Config
+
+	// Additional origins allowed on websocket upgrade requests, in addition
+	// to the request's own host (e.g. "https://ui.example.com"). Browser
+	// requests from any other origin are rejected before the connection is
+	// upgraded; requests without an Origin header are always allowed.
+	AllowedWebSocketOrigins []string
 }
 
 // DefaultConfig provides default values for server Config. The DefaultConfig is partial, so
@@ -107,9 +114,10 @@ var DefaultConfig = Config{
 // TODO(tallclair): Add auth(n/z) interface & handling.
 func NewServer(config Config, runtime Runtime) (Server, error) {
 	s := &server{
-		config:  config,
-		runtime: &criAdapter{runtime},
-		cache:   newRequestCache(),
+		config:      config,
+		runtime:     &criAdapter{runtime},
+		cache:       newRequestCache(),
+		originCheck: wsstream.OriginAllowlist(config.AllowedWebSocketOrigins),
 	}
 
 	if s.config.BaseURL == nil {
@@ -154,11 +162,12 @@ func NewServer(config Config, runtime Runtime) (Server, error) {
 }
 
 type server struct {
-	config  Config
-	runtime *criAdapter
-	handler http.Handler
-	cache   *requestCache
-	server  *http.Server
+	config      Config
+	runtime     *criAdapter
+	handler     http.Handler
+	cache       *requestCache
+	server      *http.Server
+	originCheck wsstream.OriginChecker
 }
 
 func validateExecRequest(req *runtimeapi.ExecRequest) error {
@@ -262,7 +271,25 @@ func (s *server) buildURL(method, token string) string {
 	}).String()
 }
 
+// rejectDisallowedOrigin enforces the websocket origin policy before the
+// connection is upgraded, and reports whether the request was rejected.
+// Non-websocket (SPDY) requests pass through; SPDY clients do not send
+// browser origins.
+func (s *server) rejectDisallowedOrigin(req *restful.Request, resp *restful.Response) bool {
+	if !wsstream.IsWebSocketRequest(req.Request) {
+		return false
+	}
+	if err := s.originCheck(req.Request); err != nil {
+		resp.WriteError(http.StatusForbidden, err)
+		return true
+	}
+	return false
+}
+
 func (s *server) serveExec(req *restful.Request, resp *restful.Response) {
+	if s.rejectDisallowedOrigin(req, resp) {
+		return
+	}
 	token := req.PathParameter("token")
 	cachedRequest, ok := s.cache.Consume(token)
 	if !ok {
@@ -297,6 +324,9 @@ func (s *server) serveExec(req *restful.Request, resp *restful.Response) {
 }
 
 func (s *server) serveAttach(req *restful.Request, resp *restful.Response) {
+	if s.rejectDisallowedOrigin(req, resp) {
+		return
+	}
 	token := req.PathParameter("token")
 	cachedRequest, ok := s.cache.Consume(token)
 	if !ok {
@@ -329,6 +359,9 @@ func (s *server) serveAttach(req *restful.Request, resp *restful.Response) {
 }
 
 func (s *server) servePortForward(req *restful.Request, resp *restful.Response) {
+	if s.rejectDisallowedOrigin(req, resp) {
+		return
+	}
 	token := req.PathParameter("token")
 	cachedRequest, ok := s.cache.Consume(token)
 	if !ok {
@@ -467,3 +467,33 @@ func writeExpected(t *testing.T, streamName string, w io.Writer, data string) {
 	assert.NoError(t, err, "stream %s", streamName)
 	assert.Equal(t, len(data), n, "stream %s", streamName)
 }
+
+func TestWebSocketOriginPolicy(t *testing.T) {
+	s, testServer := startTestServer(t)
+	defer testServer.Close()
+
+	resp, err := s.GetExec(&runtimeapi.ExecRequest{
+		ContainerId: testContainerID,
+		Cmd:         []string{"echo"},
+		Stdout:      true,
+	})
+	require.NoError(t, err)
+
+	// websocket upgrade requests from a foreign origin are rejected before
+	// the connection is upgraded or the token consumed
+	req := httptest.NewRequest("GET", resp.Url, nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Origin", "https://attacker.example")
+	recorder := httptest.NewRecorder()
+	s.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+
+	// the policy only applies to websocket upgrades; other requests with a
+	// foreign Origin pass through
+	req = httptest.NewRequest("GET", resp.Url, nil)
+	req.Header.Set("Origin", "https://attacker.example")
+	recorder = httptest.NewRecorder()
+	s.ServeHTTP(recorder, req)
+	assert.NotEqual(t, http.StatusForbidden, recorder.Code)
+}
@@ -94,7 +94,9 @@ const (
 
 // Config event reason list
 const (
-	FailedValidation = "FailedValidation"
+	FailedValidation      = "FailedValidation"
+	PodSourceConflict     = "PodSourceConflict"
+	FailedStaticPodDecode = "FailedStaticPodDecode"
 )
 
 // Lifecycle hooks
@@ -277,7 +277,13 @@ func makePodSourceConfig(kubeCfg *kubeletconfiginternal.KubeletConfiguration, ku
 	// define file config source
 	if kubeCfg.StaticPodPath != "" {
 		klog.InfoS("Adding static pod path", "path", kubeCfg.StaticPodPath)
-		config.NewSourceFile(kubeCfg.StaticPodPath, nodeName, kubeCfg.FileCheckFrequency.Duration, cfg.Channel(kubetypes.FileSource))
+		nodeRef := &v1.ObjectReference{
+			Kind:      "Node",
+			Name:      string(nodeName),
+			UID:       types.UID(nodeName),
+			Namespace: "",
+		}
+		config.NewSourceFileWithEvents(kubeCfg.StaticPodPath, nodeName, kubeCfg.FileCheckFrequency.Duration, cfg.Channel(kubetypes.FileSource), kubeDeps.Recorder, nodeRef)
 	}
 
 	// define url config source
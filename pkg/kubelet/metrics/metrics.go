@@ -47,6 +47,8 @@ const (
 	EvictionsKey                 = "evictions"
 	EvictionStatsAgeKey          = "eviction_stats_age_seconds"
 	PreemptionsKey               = "preemptions"
+	PodSourceConflictsKey        = "pod_source_conflicts_total"
+	HTTPSourceFetchFailuresKey   = "http_source_fetch_failures_total"
 	VolumeStatsCapacityBytesKey  = "volume_stats_capacity_bytes"
 	VolumeStatsAvailableBytesKey = "volume_stats_available_bytes"
 	VolumeStatsUsedBytesKey      = "volume_stats_used_bytes"
@@ -275,6 +277,28 @@ var (
 		},
 		[]string{"preemption_signal"},
 	)
+	// PodSourceConflicts is a Counter that tracks the cumulative number of pod configurations received
+	// from a source while a pod with the same name was already provided by another source.
+	// Broken down by the conflicting source and the source that won.
+	PodSourceConflicts = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      KubeletSubsystem,
+			Name:           PodSourceConflictsKey,
+			Help:           "Cumulative number of pod config conflicts between sources by conflicting source and winning source",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"source", "winning_source"},
+	)
+	// HTTPSourceFetchFailures is a Counter that tracks the cumulative number of failures fetching or
+	// decoding the static pod manifest URL.
+	HTTPSourceFetchFailures = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Subsystem:      KubeletSubsystem,
+			Name:           HTTPSourceFetchFailuresKey,
+			Help:           "Cumulative number of failures fetching or decoding the static pod manifest URL",
+			StabilityLevel: metrics.ALPHA,
+		},
+	)
 	// DevicePluginRegistrationCount is a Counter that tracks the cumulative number of device plugin registrations.
 	// Broken down by resource name.
 	DevicePluginRegistrationCount = metrics.NewCounterVec(
@@ -522,6 +546,8 @@ func Register(collectors ...metrics.StableCollector) {
 		legacyregistry.MustRegister(Evictions)
 		legacyregistry.MustRegister(EvictionStatsAge)
 		legacyregistry.MustRegister(Preemptions)
+		legacyregistry.MustRegister(PodSourceConflicts)
+		legacyregistry.MustRegister(HTTPSourceFetchFailures)
 		legacyregistry.MustRegister(DevicePluginRegistrationCount)
 		legacyregistry.MustRegister(DevicePluginAllocationDuration)
 		legacyregistry.MustRegister(RunningContainerCount)
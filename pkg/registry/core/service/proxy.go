@@ -26,6 +26,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/apimachinery/pkg/util/proxy"
 	"k8s.io/apiserver/pkg/registry/rest"
+	utilproxy "k8s.io/apiserver/pkg/util/proxy"
 	api "k8s.io/kubernetes/pkg/apis/core"
 	"k8s.io/kubernetes/pkg/capabilities"
 )
@@ -67,6 +68,12 @@ func (r *ProxyREST) Connect(ctx context.Context, id string, opts runtime.Object,
 		return nil, err
 	}
 	location.Path = net.JoinPreservingTrailingSlash(location.Path, proxyOpts.Path)
+	// Retry idempotent requests once on a connection reset, steering the retry to a
+	// different endpoint when the service has more than one.
+	transport = utilproxy.NewRetryingRoundTripper(transport, func() (*url.URL, error) {
+		location, _, err := r.Redirector.ResourceLocation(ctx, id)
+		return location, err
+	})
 	// Return a proxy handler that uses the desired transport, wrapped with additional proxy handling (to get URL rewriting, X-Forwarded-* headers, etc)
 	return newThrottledUpgradeAwareProxyHandler(location, transport, true, false, responder), nil
 }
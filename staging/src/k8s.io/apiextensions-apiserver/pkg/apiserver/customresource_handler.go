@@ -43,6 +43,7 @@ import (
 	"k8s.io/apiextensions-apiserver/pkg/controller/finalizer"
 	"k8s.io/apiextensions-apiserver/pkg/controller/openapi/builder"
 	"k8s.io/apiextensions-apiserver/pkg/crdserverscheme"
+	apiextensionsfeatures "k8s.io/apiextensions-apiserver/pkg/features"
 	"k8s.io/apiextensions-apiserver/pkg/registry/customresource"
 	"k8s.io/apiextensions-apiserver/pkg/registry/customresource/tableconvertor"
 
@@ -677,6 +678,14 @@ func (r *crdHandler) getOrCreateServingInfoFor(uid types.UID, name string) (*crd
 				utilruntime.HandleError(fmt.Errorf("failed to prune defaults: %v", err))
 				return nil, fmt.Errorf("the server could not properly serve the CR schema") // validation should avoid this
 			}
+
+			if utilfeature.DefaultFeatureGate.Enabled(apiextensionsfeatures.CustomResourcePruneExemptions) {
+				if value, ok := crd.Annotations[structuralschema.PruneExemptionsAnnotation]; ok {
+					for _, unresolved := range structuralschema.ApplyPruneExemptions(s, value) {
+						klog.Warningf("Prune exemption path %q on CustomResourceDefinition %s does not resolve to a schema field, ignoring", unresolved, crd.Name)
+					}
+				}
+			}
 		}
 		structuralSchemas[v.Name] = s
 	}
@@ -811,6 +820,7 @@ func (r *crdHandler) getOrCreateServingInfoFor(uid types.UID, name string) (*crd
 				validator,
 				statusValidator,
 				structuralSchemas,
+				storageVersion,
 				statusSpec,
 				scaleSpec,
 			),
@@ -822,6 +832,7 @@ func (r *crdHandler) getOrCreateServingInfoFor(uid types.UID, name string) (*crd
 				structuralSchemas:     structuralSchemas,
 				structuralSchemaGK:    kind.GroupKind(),
 				preserveUnknownFields: crd.Spec.PreserveUnknownFields,
+				crdName:               crd.Name,
 			},
 			crd.Status.AcceptedNames.Categories,
 			table,
@@ -846,6 +857,7 @@ func (r *crdHandler) getOrCreateServingInfoFor(uid types.UID, name string) (*crd
 			structuralSchemas:     structuralSchemas,
 			structuralSchemaGK:    kind.GroupKind(),
 			preserveUnknownFields: crd.Spec.PreserveUnknownFields,
+			crdName:               crd.Name,
 		}
 		var standardSerializers []runtime.SerializerInfo
 		for _, s := range negotiatedSerializer.SupportedMediaTypes() {
@@ -1034,6 +1046,7 @@ type unstructuredNegotiatedSerializer struct {
 	structuralSchemas     map[string]*structuralschema.Structural // by version
 	structuralSchemaGK    schema.GroupKind
 	preserveUnknownFields bool
+	crdName               string
 }
 
 func (s unstructuredNegotiatedSerializer) SupportedMediaTypes() []runtime.SerializerInfo {
@@ -1076,7 +1089,7 @@ func (s unstructuredNegotiatedSerializer) EncoderForVersion(encoder runtime.Enco
 }
 
 func (s unstructuredNegotiatedSerializer) DecoderToVersion(decoder runtime.Decoder, gv runtime.GroupVersioner) runtime.Decoder {
-	d := schemaCoercingDecoder{delegate: decoder, validator: unstructuredSchemaCoercer{structuralSchemas: s.structuralSchemas, structuralSchemaGK: s.structuralSchemaGK, preserveUnknownFields: s.preserveUnknownFields}}
+	d := schemaCoercingDecoder{delegate: decoder, validator: unstructuredSchemaCoercer{structuralSchemas: s.structuralSchemas, structuralSchemaGK: s.structuralSchemaGK, preserveUnknownFields: s.preserveUnknownFields, crdName: s.crdName}}
 	return versioning.NewCodec(nil, d, runtime.UnsafeObjectConvertor(Scheme), Scheme, Scheme, unstructuredDefaulter{
 		delegate:           Scheme,
 		structuralSchemas:  s.structuralSchemas,
@@ -1181,6 +1194,7 @@ type crdConversionRESTOptionsGetter struct {
 	structuralSchemas     map[string]*structuralschema.Structural // by version
 	structuralSchemaGK    schema.GroupKind
 	preserveUnknownFields bool
+	crdName               string
 }
 
 func (t crdConversionRESTOptionsGetter) GetRESTOptions(resource schema.GroupResource) (generic.RESTOptions, error) {
@@ -1193,11 +1207,13 @@ func (t crdConversionRESTOptionsGetter) GetRESTOptions(resource schema.GroupReso
 			structuralSchemas:     t.structuralSchemas,
 			structuralSchemaGK:    t.structuralSchemaGK,
 			preserveUnknownFields: t.preserveUnknownFields,
+			crdName:               t.crdName,
 		}}
 		c := schemaCoercingConverter{delegate: t.converter, validator: unstructuredSchemaCoercer{
 			structuralSchemas:     t.structuralSchemas,
 			structuralSchemaGK:    t.structuralSchemaGK,
 			preserveUnknownFields: t.preserveUnknownFields,
+			crdName:               t.crdName,
 		}}
 		ret.StorageConfig.Codec = versioning.NewCodec(
 			ret.StorageConfig.Codec,
@@ -1296,6 +1312,10 @@ type unstructuredSchemaCoercer struct {
 	structuralSchemas     map[string]*structuralschema.Structural
 	structuralSchemaGK    schema.GroupKind
 	preserveUnknownFields bool
+
+	// crdName, if set, is used to count pruned fields in the
+	// apiserver_crd_pruned_fields_total metric.
+	crdName string
 }
 
 func (v *unstructuredSchemaCoercer) apply(u *unstructured.Unstructured) error {
@@ -1321,7 +1341,10 @@ func (v *unstructuredSchemaCoercer) apply(u *unstructured.Unstructured) error {
 	if gv.Group == v.structuralSchemaGK.Group && kind == v.structuralSchemaGK.Kind {
 		if !v.preserveUnknownFields {
 			// TODO: switch over pruning and coercing at the root to  schemaobjectmeta.Coerce too
-			structuralpruning.Prune(u.Object, v.structuralSchemas[gv.Version], false)
+			pruned := structuralpruning.PruneWithOptions(u.Object, v.structuralSchemas[gv.Version], false, structuralpruning.PruneOptions{})
+			if len(v.crdName) > 0 {
+				observePrunedFields(v.crdName, pruned.PrunedCounts)
+			}
 			structuraldefaulting.PruneNonNullableNullsWithoutDefaults(u.Object, v.structuralSchemas[gv.Version])
 		}
 		if err := schemaobjectmeta.Coerce(nil, u.Object, v.structuralSchemas[gv.Version], false, v.dropInvalidMetadata); err != nil {
@@ -0,0 +1,44 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+var prunedFieldsTotal = metrics.NewCounterVec(
+	&metrics.CounterOpts{
+		Name:           "apiserver_crd_pruned_fields_total",
+		Help:           "Number of unknown fields pruned from custom resources during deserialization, broken out by CRD name and top-level field.",
+		StabilityLevel: metrics.ALPHA,
+	},
+	[]string{"crd_name", "field"})
+
+func init() {
+	legacyregistry.MustRegister(prunedFieldsTotal)
+}
+
+// observePrunedFields records the unknown fields pruned from an object of the
+// given CRD, counted per top-level field. The field label stays bounded
+// because known top-level fields are limited by the CRD schema and unknown
+// ones are aggregated by the pruner.
+func observePrunedFields(crdName string, counts map[string]int) {
+	for fieldName, count := range counts {
+		prunedFieldsTotal.WithLabelValues(crdName, fieldName).Add(float64(count))
+	}
+}
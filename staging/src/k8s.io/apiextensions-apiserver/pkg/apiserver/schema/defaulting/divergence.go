@@ -0,0 +1,89 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaulting
+
+import (
+	"reflect"
+	"sort"
+
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// DivergentDefaults returns the paths of fields in x whose value equals the
+// default declared in requestSchema while storageSchema declares a different
+// default, or none at all. Such values may have been produced solely by
+// defaulting at the request version and would come out differently if the
+// object were written through the storage version. It is meant to run after
+// Default(x, requestSchema). Paths are sorted by their rendered form.
+func DivergentDefaults(x interface{}, requestSchema, storageSchema *structuralschema.Structural) []*field.Path {
+	var paths []*field.Path
+	divergentDefaults(x, nil, requestSchema, storageSchema, &paths)
+	sort.Slice(paths, func(i, j int) bool { return paths[i].String() < paths[j].String() })
+	return paths
+}
+
+func divergentDefaults(x interface{}, pth *field.Path, request, storage *structuralschema.Structural, paths *[]*field.Path) {
+	if request == nil || storage == nil {
+		return
+	}
+
+	switch x := x.(type) {
+	case map[string]interface{}:
+		for k, v := range x {
+			reqProp, reqOK := request.Properties[k]
+			if !reqOK {
+				continue
+			}
+			storProp, storOK := storage.Properties[k]
+
+			kPth := childPath(pth, k)
+			if reqProp.Default.Object != nil && reflect.DeepEqual(v, reqProp.Default.Object) {
+				var storageDefault interface{}
+				if storOK {
+					storageDefault = storProp.Default.Object
+				}
+				if !reflect.DeepEqual(reqProp.Default.Object, storageDefault) {
+					*paths = append(*paths, kPth)
+				}
+			}
+			if storOK {
+				divergentDefaults(v, kPth, &reqProp, &storProp, paths)
+			}
+		}
+	case []interface{}:
+		for i, v := range x {
+			divergentDefaults(v, itemPath(pth, i), request.Items, storage.Items, paths)
+		}
+	default:
+		// scalars, do nothing
+	}
+}
+
+func childPath(pth *field.Path, key string) *field.Path {
+	if pth == nil {
+		return field.NewPath(key)
+	}
+	return pth.Child(key)
+}
+
+func itemPath(pth *field.Path, i int) *field.Path {
+	if pth == nil {
+		return field.NewPath("").Index(i)
+	}
+	return pth.Index(i)
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaulting
+
+import (
+	"reflect"
+	"testing"
+
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apimachinery/pkg/util/json"
+)
+
+func TestDivergentDefaults(t *testing.T) {
+	withDefault := func(d interface{}) structuralschema.Structural {
+		return structuralschema.Structural{Generic: structuralschema.Generic{Default: structuralschema.JSON{Object: d}}}
+	}
+
+	tests := []struct {
+		name          string
+		json          string
+		requestSchema *structuralschema.Structural
+		storageSchema *structuralschema.Structural
+		expected      []string
+	}{
+		{"nil schemas", `{"a":"A"}`, nil, nil, nil},
+		{"identical defaults", `{"a":"A"}`, &structuralschema.Structural{
+			Properties: map[string]structuralschema.Structural{"a": withDefault("A")},
+		}, &structuralschema.Structural{
+			Properties: map[string]structuralschema.Structural{"a": withDefault("A")},
+		}, nil},
+		{"changed default", `{"a":"A"}`, &structuralschema.Structural{
+			Properties: map[string]structuralschema.Structural{"a": withDefault("A")},
+		}, &structuralschema.Structural{
+			Properties: map[string]structuralschema.Structural{"a": withDefault("B")},
+		}, []string{"a"}},
+		{"removed default", `{"a":"A"}`, &structuralschema.Structural{
+			Properties: map[string]structuralschema.Structural{"a": withDefault("A")},
+		}, &structuralschema.Structural{
+			Properties: map[string]structuralschema.Structural{"a": {}},
+		}, []string{"a"}},
+		{"value differs from request default", `{"a":"explicit"}`, &structuralschema.Structural{
+			Properties: map[string]structuralschema.Structural{"a": withDefault("A")},
+		}, &structuralschema.Structural{
+			Properties: map[string]structuralschema.Structural{"a": withDefault("B")},
+		}, nil},
+		{"nested and array items", `{"spec":{"a":"A","list":[{"b":"B"}]}}`, &structuralschema.Structural{
+			Properties: map[string]structuralschema.Structural{
+				"spec": {
+					Properties: map[string]structuralschema.Structural{
+						"a": withDefault("A"),
+						"list": {
+							Items: &structuralschema.Structural{
+								Properties: map[string]structuralschema.Structural{"b": withDefault("B")},
+							},
+						},
+					},
+				},
+			},
+		}, &structuralschema.Structural{
+			Properties: map[string]structuralschema.Structural{
+				"spec": {
+					Properties: map[string]structuralschema.Structural{
+						"a": withDefault("A"),
+						"list": {
+							Items: &structuralschema.Structural{
+								Properties: map[string]structuralschema.Structural{"b": withDefault("C")},
+							},
+						},
+					},
+				},
+			},
+		}, []string{"spec.list[0].b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var obj interface{}
+			if err := json.Unmarshal([]byte(tt.json), &obj); err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, pth := range DivergentDefaults(obj, tt.requestSchema, tt.storageSchema) {
+				got = append(got, pth.String())
+			}
+			if !reflect.DeepEqual(tt.expected, got) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"strings"
+)
+
+// PruneExemptionsAnnotation is a CRD annotation holding a comma-separated
+// list of dot-separated field paths (e.g. "spec.template,status.conditions")
+// to exempt from pruning, as if x-kubernetes-preserve-unknown-fields were set
+// on them. List fields are named without an index; the exemption applies to
+// the items. It is an emergency lever to stop a schema mistake from
+// destroying data without editing the schema, honored only when the
+// CustomResourcePruneExemptions feature gate is enabled, and is not meant to
+// stay in place: the schema should be fixed and the annotation removed.
+const PruneExemptionsAnnotation = "apiextensions.k8s.io/prune-exemptions"
+
+// ApplyPruneExemptions marks the fields named by the annotation value as
+// preserving unknown fields. Paths that do not resolve to a schema field are
+// returned so the caller can surface them; the remaining paths are still
+// applied.
+func ApplyPruneExemptions(s *Structural, value string) []string {
+	var unresolved []string
+	for _, path := range strings.Split(value, ",") {
+		path = strings.TrimSpace(path)
+		if len(path) == 0 {
+			continue
+		}
+		if !applyPruneExemption(s, strings.Split(path, ".")) {
+			unresolved = append(unresolved, path)
+		}
+	}
+	return unresolved
+}
+
+func applyPruneExemption(s *Structural, segments []string) bool {
+	if s == nil {
+		return false
+	}
+	if len(segments) == 0 {
+		s.XPreserveUnknownFields = true
+		return true
+	}
+	// paths name list item fields directly, without an index
+	if s.Items != nil {
+		return applyPruneExemption(s.Items, segments)
+	}
+	child, ok := s.Properties[segments[0]]
+	if !ok {
+		return false
+	}
+	if !applyPruneExemption(&child, segments[1:]) {
+		return false
+	}
+	s.Properties[segments[0]] = child
+	return true
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyPruneExemptions(t *testing.T) {
+	newSchema := func() *Structural {
+		return &Structural{
+			Generic: Generic{Type: "object"},
+			Properties: map[string]Structural{
+				"spec": {
+					Generic: Generic{Type: "object"},
+					Properties: map[string]Structural{
+						"foo": {Generic: Generic{Type: "object"}},
+						"containers": {
+							Generic: Generic{Type: "array"},
+							Items: &Structural{
+								Generic: Generic{Type: "object"},
+								Properties: map[string]Structural{
+									"env": {Generic: Generic{Type: "object"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		value            string
+		wantUnresolved   []string
+		wantPreserved    func(s *Structural) bool
+		wantNotPreserved func(s *Structural) bool
+	}{
+		{
+			name:  "single field",
+			value: "spec.foo",
+			wantPreserved: func(s *Structural) bool {
+				return s.Properties["spec"].Properties["foo"].XPreserveUnknownFields
+			},
+			wantNotPreserved: func(s *Structural) bool {
+				return !s.Properties["spec"].XPreserveUnknownFields
+			},
+		},
+		{
+			name:  "list item field without index",
+			value: "spec.containers.env",
+			wantPreserved: func(s *Structural) bool {
+				return s.Properties["spec"].Properties["containers"].Items.Properties["env"].XPreserveUnknownFields
+			},
+		},
+		{
+			name:           "multiple paths with an unresolved one",
+			value:          "spec.foo, spec.missing",
+			wantUnresolved: []string{"spec.missing"},
+			wantPreserved: func(s *Structural) bool {
+				return s.Properties["spec"].Properties["foo"].XPreserveUnknownFields
+			},
+		},
+		{
+			name:           "unresolved only",
+			value:          "status",
+			wantUnresolved: []string{"status"},
+		},
+		{
+			name:  "empty value",
+			value: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSchema()
+			unresolved := ApplyPruneExemptions(s, tt.value)
+			if !reflect.DeepEqual(unresolved, tt.wantUnresolved) {
+				t.Errorf("unexpected unresolved paths, got %v, want %v", unresolved, tt.wantUnresolved)
+			}
+			if tt.wantPreserved != nil && !tt.wantPreserved(s) {
+				t.Errorf("expected field to preserve unknown fields after applying %q", tt.value)
+			}
+			if tt.wantNotPreserved != nil && !tt.wantNotPreserved(s) {
+				t.Errorf("unexpected field preserving unknown fields after applying %q", tt.value)
+			}
+		})
+	}
+}
@@ -17,13 +17,48 @@ limitations under the License.
 package pruning
 
 import (
+	"sort"
+
 	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 )
 
+// PruneOptions sets options for pruning.
+type PruneOptions struct {
+	// TrackPrunedFieldPaths defines whether to collect the paths of the
+	// fields removed by pruning. Tracking paths allocates; leave it off
+	// unless the caller consumes the result.
+	TrackPrunedFieldPaths bool
+}
+
+// PruneResult describes the fields removed by a pruning pass.
+type PruneResult struct {
+	// PrunedPaths holds the paths of the pruned fields as structured field
+	// paths (sorted by their rendered form, e.g. "spec.containers[2].unknown").
+	// It is only populated if PruneOptions.TrackPrunedFieldPaths is set.
+	// Callers needing a rendered path call String() on the elements.
+	PrunedPaths []*field.Path
+	// PrunedCounts holds the number of pruned fields keyed by the top-level
+	// field they were found under (e.g. "spec"). Unknown top-level fields are
+	// aggregated under UnknownTopLevelField to keep the key space bounded by
+	// the schema's properties. It is always collected, without constructing
+	// paths, and is nil if nothing was pruned.
+	PrunedCounts map[string]int
+}
+
+// UnknownTopLevelField is the PrunedCounts key aggregating pruned top-level
+// fields, whose names are not bounded by the schema's properties.
+const UnknownTopLevelField = "<unknown>"
+
 // Prune removes object fields in obj which are not specified in s. It skips TypeMeta and ObjectMeta fields
 // if XEmbeddedResource is set to true, or for the root if isResourceRoot=true, i.e. it does not
 // prune unknown metadata fields.
 func Prune(obj interface{}, s *structuralschema.Structural, isResourceRoot bool) {
+	PruneWithOptions(obj, s, isResourceRoot, PruneOptions{})
+}
+
+// PruneWithOptions behaves as Prune, and additionally reports what was pruned.
+func PruneWithOptions(obj interface{}, s *structuralschema.Structural, isResourceRoot bool, opts PruneOptions) PruneResult {
 	if isResourceRoot {
 		if s == nil {
 			s = &structuralschema.Structural{}
@@ -34,7 +69,12 @@ func Prune(obj interface{}, s *structuralschema.Structural, isResourceRoot bool)
 			s = &clone
 		}
 	}
-	prune(obj, s)
+	p := pruner{opts: opts}
+	p.prune(obj, nil, "", s)
+	if opts.TrackPrunedFieldPaths {
+		sort.Slice(p.prunedPaths, func(i, j int) bool { return p.prunedPaths[i].String() < p.prunedPaths[j].String() })
+	}
+	return PruneResult{PrunedPaths: p.prunedPaths, PrunedCounts: p.prunedCounts}
 }
 
 var metaFields = map[string]bool{
@@ -43,9 +83,62 @@ var metaFields = map[string]bool{
 	"metadata":   true,
 }
 
-func prune(x interface{}, s *structuralschema.Structural) {
+type pruner struct {
+	opts         PruneOptions
+	prunedPaths  []*field.Path
+	prunedCounts map[string]int
+}
+
+// recordPrunedField counts a pruned field under its top-level field root, and
+// records its full path when tracking is enabled.
+func (p *pruner) recordPrunedField(pth *field.Path, root, key string) {
+	if root == "" {
+		root = UnknownTopLevelField
+	}
+	if p.prunedCounts == nil {
+		p.prunedCounts = map[string]int{}
+	}
+	p.prunedCounts[root]++
+	if !p.opts.TrackPrunedFieldPaths {
+		return
+	}
+	p.prunedPaths = append(p.prunedPaths, p.childPath(pth, key))
+}
+
+// childPath and itemPath only construct paths when tracking is enabled, so
+// pruning without tracking does not allocate a path per visited field.
+
+func (p *pruner) childPath(pth *field.Path, key string) *field.Path {
+	if !p.opts.TrackPrunedFieldPaths {
+		return nil
+	}
+	if pth == nil {
+		return field.NewPath(key)
+	}
+	return pth.Child(key)
+}
+
+func (p *pruner) itemPath(pth *field.Path, i int) *field.Path {
+	if !p.opts.TrackPrunedFieldPaths {
+		return nil
+	}
+	if pth == nil {
+		return field.NewPath("").Index(i)
+	}
+	return pth.Index(i)
+}
+
+// childRoot returns the top-level field root for a descent into key.
+func childRoot(root, key string) string {
+	if root == "" {
+		return key
+	}
+	return root
+}
+
+func (p *pruner) prune(x interface{}, pth *field.Path, root string, s *structuralschema.Structural) {
 	if s != nil && s.XPreserveUnknownFields {
-		skipPrune(x, s)
+		p.skipPrune(x, pth, root, s)
 		return
 	}
 
@@ -53,6 +146,7 @@ func prune(x interface{}, s *structuralschema.Structural) {
 	case map[string]interface{}:
 		if s == nil {
 			for k := range x {
+				p.recordPrunedField(pth, root, k)
 				delete(x, k)
 			}
 			return
@@ -63,29 +157,30 @@ func prune(x interface{}, s *structuralschema.Structural) {
 			}
 			prop, ok := s.Properties[k]
 			if ok {
-				prune(v, &prop)
+				p.prune(v, p.childPath(pth, k), childRoot(root, k), &prop)
 			} else if s.AdditionalProperties != nil {
-				prune(v, s.AdditionalProperties.Structural)
+				p.prune(v, p.childPath(pth, k), childRoot(root, k), s.AdditionalProperties.Structural)
 			} else {
+				p.recordPrunedField(pth, root, k)
 				delete(x, k)
 			}
 		}
 	case []interface{}:
 		if s == nil {
-			for _, v := range x {
-				prune(v, nil)
+			for i, v := range x {
+				p.prune(v, p.itemPath(pth, i), root, nil)
 			}
 			return
 		}
-		for _, v := range x {
-			prune(v, s.Items)
+		for i, v := range x {
+			p.prune(v, p.itemPath(pth, i), root, s.Items)
 		}
 	default:
 		// scalars, do nothing
 	}
 }
 
-func skipPrune(x interface{}, s *structuralschema.Structural) {
+func (p *pruner) skipPrune(x interface{}, pth *field.Path, root string, s *structuralschema.Structural) {
 	if s == nil {
 		return
 	}
@@ -97,14 +192,14 @@ func skipPrune(x interface{}, s *structuralschema.Structural) {
 				continue
 			}
 			if prop, ok := s.Properties[k]; ok {
-				prune(v, &prop)
+				p.prune(v, p.childPath(pth, k), childRoot(root, k), &prop)
 			} else if s.AdditionalProperties != nil {
-				prune(v, s.AdditionalProperties.Structural)
+				p.prune(v, p.childPath(pth, k), childRoot(root, k), s.AdditionalProperties.Structural)
 			}
 		}
 	case []interface{}:
-		for _, v := range x {
-			skipPrune(v, s.Items)
+		for i, v := range x {
+			p.skipPrune(v, p.itemPath(pth, i), root, s.Items)
 		}
 	default:
 		// scalars, do nothing
@@ -655,3 +655,58 @@ func BenchmarkUnmarshal(b *testing.B) {
 		}
 	}
 }
+
+func TestPruneWithOptionsTracksPrunedFieldPaths(t *testing.T) {
+	var obj map[string]interface{}
+	input := `{"apiVersion":"example.com/v1","kind":"Foo","unknownTop":1,"spec":{"known":"x","unknown":"y","list":[{"known":true,"alsoUnknown":2}]}}`
+	if err := json.Unmarshal([]byte(input), &obj); err != nil {
+		t.Fatal(err)
+	}
+
+	schema := &structuralschema.Structural{
+		Properties: map[string]structuralschema.Structural{
+			"spec": {
+				Properties: map[string]structuralschema.Structural{
+					"known": {Generic: structuralschema.Generic{Type: "string"}},
+					"list": {
+						Generic: structuralschema.Generic{Type: "array"},
+						Items: &structuralschema.Structural{
+							Properties: map[string]structuralschema.Structural{
+								"known": {Generic: structuralschema.Generic{Type: "boolean"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	pruned := PruneWithOptions(obj, schema, true, PruneOptions{TrackPrunedFieldPaths: true})
+	prunedStrings := make([]string, 0, len(pruned.PrunedPaths))
+	for _, pth := range pruned.PrunedPaths {
+		prunedStrings = append(prunedStrings, pth.String())
+	}
+	expected := []string{"spec.list[0].alsoUnknown", "spec.unknown", "unknownTop"}
+	if !reflect.DeepEqual(prunedStrings, expected) {
+		t.Errorf("expected pruned paths %v, got %v", expected, prunedStrings)
+	}
+	expectedCounts := map[string]int{"spec": 2, UnknownTopLevelField: 1}
+	if !reflect.DeepEqual(pruned.PrunedCounts, expectedCounts) {
+		t.Errorf("expected pruned counts %v, got %v", expectedCounts, pruned.PrunedCounts)
+	}
+
+	// tracking disabled returns no paths, but counts and pruning still happen
+	if err := json.Unmarshal([]byte(input), &obj); err != nil {
+		t.Fatal(err)
+	}
+	pruned = PruneWithOptions(obj, schema, true, PruneOptions{})
+	if pruned.PrunedPaths != nil {
+		t.Errorf("expected nil pruned paths without tracking, got %v", pruned.PrunedPaths)
+	}
+	if !reflect.DeepEqual(pruned.PrunedCounts, expectedCounts) {
+		t.Errorf("expected pruned counts %v, got %v", expectedCounts, pruned.PrunedCounts)
+	}
+	if _, found := obj["unknownTop"]; found {
+		t.Errorf("expected unknownTop to be pruned")
+	}
+}
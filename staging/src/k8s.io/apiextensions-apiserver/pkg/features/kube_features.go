@@ -22,11 +22,19 @@ import (
 )
 
 const (
-// Every feature gate should add method here following this template:
-//
-// // owner: @username
-// // alpha: v1.4
-// MyFeature() bool
+	// Every feature gate should add method here following this template:
+	//
+	// // owner: @username
+	// // alpha: v1.4
+	// MyFeature() bool
+
+	// owner: @liggitt
+	// alpha: v1.22
+	//
+	// Honor the apiextensions.k8s.io/prune-exemptions CRD annotation, which
+	// temporarily exempts the listed field paths from pruning as an emergency
+	// recovery lever when a schema mistake is destroying data.
+	CustomResourcePruneExemptions featuregate.Feature = "CustomResourcePruneExemptions"
 )
 
 func init() {
@@ -36,4 +44,6 @@ func init() {
 // defaultKubernetesFeatureGates consists of all known Kubernetes-specific feature keys.
 // To add a new feature, define a key for it above and add it here. The features will be
 // available throughout Kubernetes binaries.
-var defaultKubernetesFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{}
+var defaultKubernetesFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
+	CustomResourcePruneExemptions: {Default: false, PreRelease: featuregate.Alpha},
+}
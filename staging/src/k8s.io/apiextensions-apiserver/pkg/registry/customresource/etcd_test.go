@@ -101,6 +101,7 @@ func newStorage(t *testing.T) (customresource.CustomResourceStorage, *etcd3testi
 			nil,
 			nil,
 			nil,
+			kind.Version,
 			status,
 			scale,
 		),
@@ -18,6 +18,7 @@ package customresource
 
 import (
 	"context"
+	"fmt"
 
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -30,10 +31,12 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	apiserverstorage "k8s.io/apiserver/pkg/storage"
 	"k8s.io/apiserver/pkg/storage/names"
+	"k8s.io/apiserver/pkg/warning"
 	"k8s.io/kube-openapi/pkg/validation/validate"
 
 	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	structuraldefaulting "k8s.io/apiextensions-apiserver/pkg/apiserver/schema/defaulting"
 	structurallisttype "k8s.io/apiextensions-apiserver/pkg/apiserver/schema/listtype"
 	schemaobjectmeta "k8s.io/apiextensions-apiserver/pkg/apiserver/schema/objectmeta"
 	"sigs.k8s.io/structured-merge-diff/v4/fieldpath"
@@ -47,12 +50,13 @@ type customResourceStrategy struct {
 	namespaceScoped   bool
 	validator         customResourceValidator
 	structuralSchemas map[string]*structuralschema.Structural
+	storageVersion    string
 	status            *apiextensions.CustomResourceSubresourceStatus
 	scale             *apiextensions.CustomResourceSubresourceScale
 	kind              schema.GroupVersionKind
 }
 
-func NewStrategy(typer runtime.ObjectTyper, namespaceScoped bool, kind schema.GroupVersionKind, schemaValidator, statusSchemaValidator *validate.SchemaValidator, structuralSchemas map[string]*structuralschema.Structural, status *apiextensions.CustomResourceSubresourceStatus, scale *apiextensions.CustomResourceSubresourceScale) customResourceStrategy {
+func NewStrategy(typer runtime.ObjectTyper, namespaceScoped bool, kind schema.GroupVersionKind, schemaValidator, statusSchemaValidator *validate.SchemaValidator, structuralSchemas map[string]*structuralschema.Structural, storageVersion string, status *apiextensions.CustomResourceSubresourceStatus, scale *apiextensions.CustomResourceSubresourceScale) customResourceStrategy {
 	return customResourceStrategy{
 		ObjectTyper:     typer,
 		NameGenerator:   names.SimpleNameGenerator,
@@ -66,6 +70,7 @@ func NewStrategy(typer runtime.ObjectTyper, namespaceScoped bool, kind schema.Gr
 			statusSchemaValidator: statusSchemaValidator,
 		},
 		structuralSchemas: structuralSchemas,
+		storageVersion:    storageVersion,
 		kind:              kind,
 	}
 }
@@ -100,6 +105,8 @@ func (a customResourceStrategy) PrepareForCreate(ctx context.Context, obj runtim
 
 	accessor, _ := meta.Accessor(obj)
 	accessor.SetGeneration(1)
+
+	a.warnDivergentDefaults(ctx, obj)
 }
 
 // PrepareForUpdate clears fields that are not allowed to be set by end users on update.
@@ -131,6 +138,30 @@ func (a customResourceStrategy) PrepareForUpdate(ctx context.Context, obj, old r
 		newAccessor, _ := meta.Accessor(newCustomResourceObject)
 		newAccessor.SetGeneration(oldAccessor.GetGeneration() + 1)
 	}
+
+	a.warnDivergentDefaults(ctx, obj)
+}
+
+// warnDivergentDefaults warns about fields that carry the request version's
+// default value where the storage version's schema defaults differently. Such
+// values may have been filled in solely by defaulting at the request version,
+// so the stored object depends on which version the client wrote through.
+func (a customResourceStrategy) warnDivergentDefaults(ctx context.Context, obj runtime.Object) {
+	if a.storageVersion == "" || a.kind.Version == a.storageVersion {
+		return
+	}
+	requestSchema := a.structuralSchemas[a.kind.Version]
+	storageSchema := a.structuralSchemas[a.storageVersion]
+	if requestSchema == nil || storageSchema == nil {
+		return
+	}
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return
+	}
+	for _, pth := range structuraldefaulting.DivergentDefaults(u.UnstructuredContent(), requestSchema, storageSchema) {
+		warning.AddWarning(ctx, "", fmt.Sprintf("%s: value matches the default of %s, which differs from the default in storage version %s; set the field explicitly if this value is intended", pth, a.kind.GroupVersion(), a.storageVersion))
+	}
 }
 
 func copyNonMetadata(original map[string]interface{}) map[string]interface{} {
@@ -0,0 +1,58 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+// SumQuantities returns the sum of the given quantities. The result carries
+// the format of the first quantity. Sums that overflow int64 are handled by
+// Quantity's arbitrary precision fallback, so the result is always exact.
+func SumQuantities(quantities ...Quantity) Quantity {
+	var sum Quantity
+	for i := range quantities {
+		if i == 0 {
+			sum = quantities[i].DeepCopy()
+			continue
+		}
+		sum.Add(quantities[i])
+	}
+	return sum
+}
+
+// MaxQuantity returns a copy of the largest of the given quantities, or the
+// zero quantity if none are given. Negative quantities compare as expected,
+// so MaxQuantity(q, Quantity{}) clamps a possibly-negative value to zero.
+func MaxQuantity(quantities ...Quantity) Quantity {
+	var max Quantity
+	for i := range quantities {
+		if i == 0 || quantities[i].Cmp(max) > 0 {
+			max = quantities[i]
+		}
+	}
+	return max.DeepCopy()
+}
+
+// AddInto accumulates q into m[name], creating the entry if it is absent.
+// It exists so aggregation loops do not need the read-modify-write dance
+// required because map values are not addressable.
+func AddInto(m map[string]Quantity, name string, q Quantity) {
+	sum, found := m[name]
+	if !found {
+		m[name] = q.DeepCopy()
+		return
+	}
+	sum.Add(q)
+	m[name] = sum
+}
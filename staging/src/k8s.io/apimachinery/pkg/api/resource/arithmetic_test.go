@@ -0,0 +1,87 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resource
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSumQuantities(t *testing.T) {
+	table := []struct {
+		name       string
+		quantities []Quantity
+		expected   string
+	}{
+		{"empty", nil, "0"},
+		{"single", []Quantity{MustParse("1Gi")}, "1Gi"},
+		{"mixed scales", []Quantity{MustParse("1"), MustParse("500m"), MustParse("250m")}, "1750m"},
+		{"negative values", []Quantity{MustParse("2"), MustParse("-3")}, "-1"},
+		{"int64 overflow", []Quantity{*NewQuantity(math.MaxInt64, DecimalSI), *NewQuantity(math.MaxInt64, DecimalSI)}, "18446744073709551614"},
+	}
+	for _, item := range table {
+		t.Run(item.name, func(t *testing.T) {
+			sum := SumQuantities(item.quantities...)
+			if expected := MustParse(item.expected); sum.Cmp(expected) != 0 {
+				t.Errorf("expected %s, got %s", expected.String(), sum.String())
+			}
+		})
+	}
+}
+
+func TestMaxQuantity(t *testing.T) {
+	table := []struct {
+		name       string
+		quantities []Quantity
+		expected   string
+	}{
+		{"empty", nil, "0"},
+		{"single", []Quantity{MustParse("1Gi")}, "1Gi"},
+		{"picks largest", []Quantity{MustParse("100m"), MustParse("2"), MustParse("1500m")}, "2"},
+		{"clamps negative to zero", []Quantity{MustParse("-5"), {}}, "0"},
+	}
+	for _, item := range table {
+		t.Run(item.name, func(t *testing.T) {
+			max := MaxQuantity(item.quantities...)
+			if expected := MustParse(item.expected); max.Cmp(expected) != 0 {
+				t.Errorf("expected %s, got %s", expected.String(), max.String())
+			}
+		})
+	}
+}
+
+func TestMaxQuantityCopies(t *testing.T) {
+	original := MustParse("1Gi")
+	max := MaxQuantity(original)
+	max.Add(MustParse("1Gi"))
+	if original.String() != "1Gi" {
+		t.Errorf("expected original quantity to be unchanged, got %s", original.String())
+	}
+}
+
+func TestAddInto(t *testing.T) {
+	m := map[string]Quantity{}
+	AddInto(m, "cpu", MustParse("500m"))
+	AddInto(m, "cpu", MustParse("250m"))
+	AddInto(m, "memory", MustParse("1Gi"))
+	if q := m["cpu"]; q.Cmp(MustParse("750m")) != 0 {
+		t.Errorf("expected cpu 750m, got %s", q.String())
+	}
+	if q := m["memory"]; q.Cmp(MustParse("1Gi")) != 0 {
+		t.Errorf("expected memory 1Gi, got %s", q.String())
+	}
+}
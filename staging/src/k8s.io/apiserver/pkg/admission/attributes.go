@@ -47,6 +47,10 @@ type attributesRecord struct {
 	annotationsLock sync.RWMutex
 
 	reinvocationContext ReinvocationContext
+
+	// views lazily caches derived views of object shared across plugins.
+	viewsOnce sync.Once
+	views     *objectViews
 }
 
 type annotation struct {
@@ -163,6 +167,13 @@ func (record *attributesRecord) GetReinvocationContext() ReinvocationContext {
 	return record.reinvocationContext
 }
 
+func (record *attributesRecord) objectViews() *objectViews {
+	record.viewsOnce.Do(func() {
+		record.views = &objectViews{attributes: record}
+	})
+	return record.views
+}
+
 type reinvocationContext struct {
 	// isReinvoke is true when admission plugins are being reinvoked
 	isReinvoke bool
@@ -0,0 +1,92 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ObjectViews caches derived views of the object carried by an
+// admission.Attributes, so that plugins needing the same view do not each
+// redo the conversion or deep copy. Views are materialized lazily on first
+// use and shared between callers; a plugin that mutates the underlying
+// object must call Invalidate so later callers see the change.
+type ObjectViews interface {
+	// UnstructuredObject returns an unstructured view of GetObject(),
+	// converting at most once until the view is invalidated. The returned
+	// object is shared and must be treated as read-only; use
+	// UnstructuredObjectCopy to obtain a mutable copy.
+	UnstructuredObject() (*unstructured.Unstructured, error)
+	// UnstructuredObjectCopy returns a deep copy of the cached unstructured
+	// view that the caller may mutate freely. The cached view is only
+	// deep-copied for callers that ask for a mutable copy.
+	UnstructuredObjectCopy() (*unstructured.Unstructured, error)
+	// Invalidate drops all cached views. Mutating plugins must call it after
+	// changing the attributes object in place.
+	Invalidate()
+}
+
+// GetObjectViews returns the shared view cache for the given attributes.
+// Attribute implementations that do not support caching get a fresh,
+// uncached view holder, which is correct but converts on every call.
+func GetObjectViews(a Attributes) ObjectViews {
+	if record, ok := a.(*attributesRecord); ok {
+		return record.objectViews()
+	}
+	return &objectViews{attributes: a}
+}
+
+type objectViews struct {
+	attributes Attributes
+
+	lock         sync.Mutex
+	unstructured *unstructured.Unstructured
+}
+
+func (v *objectViews) UnstructuredObject() (*unstructured.Unstructured, error) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	if v.unstructured == nil {
+		if u, ok := v.attributes.GetObject().(*unstructured.Unstructured); ok {
+			v.unstructured = u
+		} else {
+			content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(v.attributes.GetObject())
+			if err != nil {
+				return nil, err
+			}
+			v.unstructured = &unstructured.Unstructured{Object: content}
+		}
+	}
+	return v.unstructured, nil
+}
+
+func (v *objectViews) UnstructuredObjectCopy() (*unstructured.Unstructured, error) {
+	u, err := v.UnstructuredObject()
+	if err != nil {
+		return nil, err
+	}
+	return u.DeepCopy(), nil
+}
+
+func (v *objectViews) Invalidate() {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.unstructured = nil
+}
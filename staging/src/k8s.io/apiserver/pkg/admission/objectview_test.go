@@ -0,0 +1,117 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package admission
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func benchmarkPod() *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pod",
+			Namespace: "ns",
+			Labels:    map[string]string{"app": "test"},
+		},
+	}
+	for i := 0; i < 4; i++ {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{
+			Name:  "container",
+			Image: "busybox",
+			Env:   []corev1.EnvVar{{Name: "FOO", Value: "bar"}},
+		})
+	}
+	return pod
+}
+
+func TestObjectViewsCachesUnstructured(t *testing.T) {
+	pod := benchmarkPod()
+	attrs := NewAttributesRecord(pod, nil, schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, "ns", "pod", schema.GroupVersionResource{Version: "v1", Resource: "pods"}, "", Create, nil, false, nil)
+
+	views := GetObjectViews(attrs)
+	first, err := views.UnstructuredObject()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.GetName() != "pod" {
+		t.Errorf("unexpected unstructured view: %v", first.Object)
+	}
+	second, err := views.UnstructuredObject()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Error("expected the cached view to be returned on the second call")
+	}
+	if otherViews := GetObjectViews(attrs); otherViews != views {
+		t.Error("expected the same view cache from repeated GetObjectViews calls")
+	}
+
+	copied, err := views.UnstructuredObjectCopy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if copied == first {
+		t.Error("expected a mutable copy, got the shared view")
+	}
+
+	// after invalidation a fresh view is materialized
+	pod.Labels["app"] = "changed"
+	views.Invalidate()
+	third, err := views.UnstructuredObject()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if third == first {
+		t.Error("expected a new view after invalidation")
+	}
+	if third.GetLabels()["app"] != "changed" {
+		t.Errorf("expected the new view to observe the mutation, got labels %v", third.GetLabels())
+	}
+}
+
+// BenchmarkObjectViews compares a plugin chain converting the object once per
+// plugin against plugins sharing the cached view.
+func BenchmarkObjectViews(b *testing.B) {
+	const plugins = 5
+	pod := benchmarkPod()
+	attrs := NewAttributesRecord(pod, nil, schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, "ns", "pod", schema.GroupVersionResource{Version: "v1", Resource: "pods"}, "", Create, nil, false, nil)
+
+	b.Run("convert-per-plugin", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < plugins; j++ {
+				if _, err := (&objectViews{attributes: attrs}).UnstructuredObject(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("shared-view", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			views := &objectViews{attributes: attrs}
+			for j := 0; j < plugins; j++ {
+				if _, err := views.UnstructuredObject(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"sync"
+
+	auditinternal "k8s.io/apiserver/pkg/apis/audit"
+)
+
+const (
+	// annotationsTruncatedKey is set to "true" on events that had annotations
+	// dropped or annotation values truncated because of annotation limits, so
+	// consumers know the recorded annotations are incomplete.
+	annotationsTruncatedKey = "audit.k8s.io/annotations-truncated"
+
+	// annotationValueTruncateSuffix is appended to annotation values that were
+	// cut to the configured maximum length.
+	annotationValueTruncateSuffix = "...[truncated]"
+)
+
+// AnnotationLimits bounds the annotations recorded on a single audit event so
+// verbose admission plugins and webhooks cannot overwhelm audit backends. A
+// zero field disables the corresponding limit.
+type AnnotationLimits struct {
+	// MaxCount is the maximum number of annotations recorded per event.
+	// Annotations set beyond the limit are dropped.
+	MaxCount int
+	// MaxValueLength is the maximum length of a single annotation value.
+	// Longer values are truncated and marked with a truncation suffix.
+	MaxValueLength int
+}
+
+var (
+	annotationLimitsMutex sync.RWMutex
+	annotationLimits      AnnotationLimits
+)
+
+// SetAnnotationLimits installs the process-wide audit annotation limits. It is
+// meant to be called once during server setup, before requests are served.
+func SetAnnotationLimits(limits AnnotationLimits) {
+	annotationLimitsMutex.Lock()
+	defer annotationLimitsMutex.Unlock()
+	annotationLimits = limits
+}
+
+// applyAnnotationLimits enforces the configured limits for the key, value pair
+// about to be recorded on ae. It returns the possibly truncated value, and
+// false if the annotation must be dropped entirely.
+func applyAnnotationLimits(ae *auditinternal.Event, key, value string) (string, bool) {
+	annotationLimitsMutex.RLock()
+	limits := annotationLimits
+	annotationLimitsMutex.RUnlock()
+
+	if limits.MaxValueLength > 0 && len(value) > limits.MaxValueLength {
+		value = value[:limits.MaxValueLength] + annotationValueTruncateSuffix
+		markAnnotationsTruncated(ae, "size")
+	}
+	// updates of an already recorded key do not count against the limit, and
+	// the truncation marker is always allowed so incompleteness stays visible
+	if _, ok := ae.Annotations[key]; ok || key == annotationsTruncatedKey {
+		return value, true
+	}
+	if limits.MaxCount > 0 && len(ae.Annotations) >= limits.MaxCount {
+		markAnnotationsTruncated(ae, "count")
+		return "", false
+	}
+	return value, true
+}
+
+func markAnnotationsTruncated(ae *auditinternal.Event, reason string) {
+	annotationsTruncatedCounter.WithLabelValues(reason).Inc()
+	if ae.Annotations == nil {
+		ae.Annotations = make(map[string]string)
+	}
+	ae.Annotations[annotationsTruncatedKey] = "true"
+}
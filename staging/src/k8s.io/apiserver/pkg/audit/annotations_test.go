@@ -0,0 +1,81 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"strings"
+	"testing"
+
+	auditinternal "k8s.io/apiserver/pkg/apis/audit"
+)
+
+func TestLogAnnotationLimits(t *testing.T) {
+	defer SetAnnotationLimits(AnnotationLimits{})
+
+	t.Run("count limit", func(t *testing.T) {
+		SetAnnotationLimits(AnnotationLimits{MaxCount: 2})
+		ev := &auditinternal.Event{Level: auditinternal.LevelMetadata}
+
+		LogAnnotation(ev, "one", "1")
+		LogAnnotation(ev, "two", "2")
+		LogAnnotation(ev, "three", "3")
+
+		if _, ok := ev.Annotations["three"]; ok {
+			t.Errorf("expected annotation over the count limit to be dropped")
+		}
+		if ev.Annotations["one"] != "1" || ev.Annotations["two"] != "2" {
+			t.Errorf("expected annotations under the count limit to be kept, got %v", ev.Annotations)
+		}
+		if ev.Annotations[annotationsTruncatedKey] != "true" {
+			t.Errorf("expected truncation marker to be set, got %v", ev.Annotations)
+		}
+	})
+
+	t.Run("value length limit", func(t *testing.T) {
+		SetAnnotationLimits(AnnotationLimits{MaxValueLength: 5})
+		ev := &auditinternal.Event{Level: auditinternal.LevelMetadata}
+
+		LogAnnotation(ev, "short", "12345")
+		LogAnnotation(ev, "long", "1234567890")
+
+		if got := ev.Annotations["short"]; got != "12345" {
+			t.Errorf("expected value at the limit to be kept, got %q", got)
+		}
+		if got, want := ev.Annotations["long"], "12345"+annotationValueTruncateSuffix; got != want {
+			t.Errorf("expected truncated value %q, got %q", want, got)
+		}
+		if ev.Annotations[annotationsTruncatedKey] != "true" {
+			t.Errorf("expected truncation marker to be set, got %v", ev.Annotations)
+		}
+	})
+
+	t.Run("limits disabled", func(t *testing.T) {
+		SetAnnotationLimits(AnnotationLimits{})
+		ev := &auditinternal.Event{Level: auditinternal.LevelMetadata}
+
+		for i := 0; i < 100; i++ {
+			LogAnnotation(ev, strings.Repeat("k", i+1), strings.Repeat("v", 1000))
+		}
+
+		if len(ev.Annotations) != 100 {
+			t.Errorf("expected all annotations to be kept, got %d", len(ev.Annotations))
+		}
+		if _, ok := ev.Annotations[annotationsTruncatedKey]; ok {
+			t.Errorf("unexpected truncation marker with limits disabled")
+		}
+	})
+}
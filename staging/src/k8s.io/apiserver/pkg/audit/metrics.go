@@ -66,6 +66,18 @@ var (
 		[]string{"level"},
 	)
 
+	annotationsTruncatedCounter = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem: subsystem,
+			Name:      "annotations_truncated_total",
+			Help: "Counter of audit event annotations dropped or truncated because " +
+				"the configured annotation limits were reached. Reason identifies " +
+				"which limit was hit, count or size.",
+			StabilityLevel: metrics.ALPHA,
+		},
+		[]string{"reason"},
+	)
+
 	ApiserverAuditDroppedCounter = metrics.NewCounter(
 		&metrics.CounterOpts{
 			Subsystem: subsystem,
@@ -81,6 +93,7 @@ func init() {
 	legacyregistry.MustRegister(eventCounter)
 	legacyregistry.MustRegister(errorCounter)
 	legacyregistry.MustRegister(levelCounter)
+	legacyregistry.MustRegister(annotationsTruncatedCounter)
 	legacyregistry.MustRegister(ApiserverAuditDroppedCounter)
 }
 
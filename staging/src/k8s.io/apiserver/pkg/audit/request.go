@@ -226,6 +226,11 @@ func LogAnnotation(ae *auditinternal.Event, key, value string) {
 	if ae.Annotations == nil {
 		ae.Annotations = make(map[string]string)
 	}
+	value, ok := applyAnnotationLimits(ae, key, value)
+	if !ok {
+		klog.Warningf("Failed to set annotations[%q] for audit:%q, the configured annotation limits were reached", key, ae.AuditID)
+		return
+	}
 	if v, ok := ae.Annotations[key]; ok && v != value {
 		klog.Warningf("Failed to set annotations[%q] to %q for audit:%q, it has already been set to %q", key, value, ae.AuditID, ae.Annotations[key])
 		return
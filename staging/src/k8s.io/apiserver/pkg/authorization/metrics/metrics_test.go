@@ -0,0 +1,63 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+)
+
+type fakeAuthorizer struct {
+	decision authorizer.Decision
+	reason   string
+	err      error
+}
+
+func (f fakeAuthorizer) Authorize(ctx context.Context, attributes authorizer.Attributes) (authorizer.Decision, string, error) {
+	return f.decision, f.reason, f.err
+}
+
+func TestInstrumentedAuthorizer(t *testing.T) {
+	tests := []struct {
+		name     string
+		delegate fakeAuthorizer
+	}{
+		{"allowed", fakeAuthorizer{decision: authorizer.DecisionAllow, reason: "allowed by policy"}},
+		{"denied", fakeAuthorizer{decision: authorizer.DecisionDeny, reason: "denied by policy"}},
+		{"no opinion", fakeAuthorizer{decision: authorizer.DecisionNoOpinion}},
+		{"error", fakeAuthorizer{decision: authorizer.DecisionNoOpinion, err: errors.New("webhook unavailable")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instrumented := NewInstrumentedAuthorizer("TestMode", tt.delegate)
+			decision, reason, err := instrumented.Authorize(context.Background(), nil)
+			if decision != tt.delegate.decision {
+				t.Errorf("expected decision %v, got %v", tt.delegate.decision, decision)
+			}
+			if reason != tt.delegate.reason {
+				t.Errorf("expected reason %q, got %q", tt.delegate.reason, reason)
+			}
+			if err != tt.delegate.err {
+				t.Errorf("expected error %v, got %v", tt.delegate.err, err)
+			}
+		})
+	}
+}
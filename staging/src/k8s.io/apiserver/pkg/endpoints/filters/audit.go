@@ -23,9 +23,14 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	auditinternal "k8s.io/apiserver/pkg/apis/audit"
@@ -35,6 +40,21 @@ import (
 	"k8s.io/apiserver/pkg/endpoints/request"
 )
 
+const (
+	// responseBytesAnnotationKey carries the number of response body bytes
+	// written for the request, enabling egress-size analysis of the audit log.
+	responseBytesAnnotationKey = "apiserver.k8s.io/response-bytes"
+	// responseBytesTruncatedAnnotationKey is set to "true" when byte counting
+	// stopped early because the connection was hijacked for a protocol switch,
+	// making the recorded size a lower bound.
+	responseBytesTruncatedAnnotationKey = "apiserver.k8s.io/response-bytes-truncated"
+	// traceIDAnnotationKey and spanIDAnnotationKey carry the OpenTelemetry
+	// trace and span IDs of the request when tracing is enabled, linking the
+	// audit event to the request trace.
+	traceIDAnnotationKey = "apiserver.k8s.io/trace-id"
+	spanIDAnnotationKey  = "apiserver.k8s.io/span-id"
+)
+
 // WithAudit decorates a http.Handler with audit logging information for all the
 // requests coming to the server. Audit level is decided according to requests'
 // attributes and audit policy. Logs are emitted to the audit sink to
@@ -106,6 +126,9 @@ func WithAudit(handler http.Handler, sink audit.Sink, policy policy.Checker, lon
 			if ev.ResponseStatus == nil {
 				ev.ResponseStatus = fakedSuccessStatus
 			}
+			if rs, ok := respWriter.(interface{ logResponseBytes() }); ok {
+				rs.logResponseBytes()
+			}
 			processAuditEvent(ctx, sink, ev, omitStages)
 		}()
 		handler.ServeHTTP(respWriter, req)
@@ -141,11 +164,28 @@ func createAuditEventAndAttachToContext(req *http.Request, policy policy.Checker
 		return req, nil, nil, fmt.Errorf("failed to complete audit event from request: %v", err)
 	}
 
+	// When the request is traced, cross-link the audit event and the span so
+	// the two observability systems can be joined without log correlation.
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		audit.LogAnnotation(ev, traceIDAnnotationKey, sc.TraceID().String())
+		audit.LogAnnotation(ev, spanIDAnnotationKey, sc.SpanID().String())
+		trace.SpanFromContext(ctx).AddEvent("audit event recorded",
+			trace.WithAttributes(attribute.String("audit-id", string(ev.AuditID))))
+	}
+
 	req = req.WithContext(request.WithAuditEvent(ctx, ev))
 
 	return req, ev, omitStages, nil
 }
 
+// valueOnlyContext carries the values of its parent context without its
+// cancellation signal or deadline.
+type valueOnlyContext struct{ context.Context }
+
+func (valueOnlyContext) Deadline() (deadline time.Time, ok bool) { return }
+func (valueOnlyContext) Done() <-chan struct{}                   { return nil }
+func (valueOnlyContext) Err() error                              { return nil }
+
 func processAuditEvent(ctx context.Context, sink audit.Sink, ev *auditinternal.Event, omitStages []auditinternal.Stage) bool {
 	for _, stage := range omitStages {
 		if ev.Stage == stage {
@@ -153,6 +193,12 @@ func processAuditEvent(ctx context.Context, sink audit.Sink, ev *auditinternal.E
 		}
 	}
 
+	// Completion and panic events are emitted after the request context may
+	// already have been canceled by the timeout filter or by the client going
+	// away. Event emission must not be affected by that cancellation, so strip
+	// the cancellation signal while keeping the request-scoped values.
+	ctx = valueOnlyContext{ctx}
+
 	if ev.Stage == auditinternal.StageRequestReceived {
 		ev.StageTimestamp = metav1.NewMicroTime(ev.RequestReceivedTimestamp.Time)
 	} else {
@@ -189,11 +235,13 @@ var _ http.ResponseWriter = &auditResponseWriter{}
 // create immediately an event (for long running requests).
 type auditResponseWriter struct {
 	http.ResponseWriter
-	ctx        context.Context
-	event      *auditinternal.Event
-	once       sync.Once
-	sink       audit.Sink
-	omitStages []auditinternal.Stage
+	ctx          context.Context
+	event        *auditinternal.Event
+	once         sync.Once
+	sink         audit.Sink
+	omitStages   []auditinternal.Stage
+	bytesWritten int64
+	hijacked     uint32
 }
 
 func (a *auditResponseWriter) processCode(code int) {
@@ -213,7 +261,21 @@ func (a *auditResponseWriter) processCode(code int) {
 func (a *auditResponseWriter) Write(bs []byte) (int, error) {
 	// the Go library calls WriteHeader internally if no code was written yet. But this will go unnoticed for us
 	a.processCode(http.StatusOK)
-	return a.ResponseWriter.Write(bs)
+	n, err := a.ResponseWriter.Write(bs)
+	atomic.AddInt64(&a.bytesWritten, int64(n))
+	return n, err
+}
+
+// logResponseBytes annotates the event with the number of response body bytes
+// written, and whether counting stopped early because of a protocol switch.
+func (a *auditResponseWriter) logResponseBytes() {
+	if a.event == nil || a.event.Level.Less(auditinternal.LevelMetadata) {
+		return
+	}
+	audit.LogAnnotation(a.event, responseBytesAnnotationKey, strconv.FormatInt(atomic.LoadInt64(&a.bytesWritten), 10))
+	if atomic.LoadUint32(&a.hijacked) == 1 {
+		audit.LogAnnotation(a.event, responseBytesTruncatedAnnotationKey, "true")
+	}
 }
 
 func (a *auditResponseWriter) WriteHeader(code int) {
@@ -240,6 +302,10 @@ func (f *fancyResponseWriterDelegator) Hijack() (net.Conn, *bufio.ReadWriter, er
 	// fake a response status before protocol switch happens
 	f.processCode(http.StatusSwitchingProtocols)
 
+	// bytes exchanged on the hijacked connection bypass Write, so the recorded
+	// response size is only a lower bound from here on
+	atomic.StoreUint32(&f.hijacked, 1)
+
 	return f.ResponseWriter.(http.Hijacker).Hijack()
 }
 
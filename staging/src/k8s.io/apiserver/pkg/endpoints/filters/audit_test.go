@@ -28,6 +28,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -136,6 +137,40 @@ func TestDecorateResponseWriterWithImplicitWrite(t *testing.T) {
 	}
 }
 
+func TestDecorateResponseWriterResponseBytes(t *testing.T) {
+	ev := &auditinternal.Event{Level: auditinternal.LevelMetadata}
+	actual := decorateResponseWriter(context.Background(), &simpleResponseWriter{}, ev, nil, nil)
+
+	actual.Write([]byte("foo"))
+	actual.Write([]byte("quux"))
+	actual.(*auditResponseWriter).logResponseBytes()
+
+	if v := ev.Annotations[responseBytesAnnotationKey]; v != "7" {
+		t.Errorf("expected response bytes annotation %q, got %q", "7", v)
+	}
+	if _, found := ev.Annotations[responseBytesTruncatedAnnotationKey]; found {
+		t.Errorf("expected no truncation annotation, got %q", ev.Annotations[responseBytesTruncatedAnnotationKey])
+	}
+}
+
+func TestDecorateResponseWriterResponseBytesTruncated(t *testing.T) {
+	ev := &auditinternal.Event{Level: auditinternal.LevelMetadata}
+	actual := decorateResponseWriter(context.Background(), &fancyResponseWriter{}, ev, nil, nil)
+
+	actual.Write([]byte("foo"))
+	if _, _, err := actual.(*fancyResponseWriterDelegator).Hijack(); err != nil {
+		t.Fatal(err)
+	}
+	actual.(*fancyResponseWriterDelegator).logResponseBytes()
+
+	if v := ev.Annotations[responseBytesAnnotationKey]; v != "3" {
+		t.Errorf("expected response bytes annotation %q, got %q", "3", v)
+	}
+	if v := ev.Annotations[responseBytesTruncatedAnnotationKey]; v != "true" {
+		t.Errorf("expected truncation annotation %q, got %q", "true", v)
+	}
+}
+
 func TestDecorateResponseWriterChannel(t *testing.T) {
 	sink := &fakeAuditSink{}
 	ev := &auditinternal.Event{}
@@ -853,3 +888,55 @@ func withTestContext(req *http.Request, user user.Info, audit *auditinternal.Eve
 	}
 	return req.WithContext(ctx)
 }
+
+func TestAuditEventEmittedAfterContextCancellation(t *testing.T) {
+	sink := &fakeAuditSink{}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	ev := &auditinternal.Event{
+		Stage: auditinternal.StageResponseComplete,
+	}
+	if processed := processAuditEvent(ctx, sink, ev, nil); !processed {
+		t.Fatal("expected event to be processed after context cancellation")
+	}
+	if got := len(sink.Events()); got != 1 {
+		t.Fatalf("expected 1 event in sink, got %d", got)
+	}
+}
+
+func TestAuditEventTraceAnnotations(t *testing.T) {
+	policyChecker := policy.FakeChecker(auditinternal.LevelMetadata, nil)
+
+	req, _ := http.NewRequest("GET", "/api/v1/namespaces/default/pods", nil)
+	req = withTestContext(req, &user.DefaultInfo{Name: "admin"}, nil)
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{0x01},
+		SpanID:     trace.SpanID{0x02},
+		TraceFlags: trace.FlagsSampled,
+	})
+	req = req.WithContext(trace.ContextWithSpanContext(req.Context(), sc))
+
+	_, ev, _, err := createAuditEventAndAttachToContext(req, policyChecker)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := ev.Annotations[traceIDAnnotationKey]; got != sc.TraceID().String() {
+		t.Errorf("expected trace ID annotation %q, got %q", sc.TraceID().String(), got)
+	}
+	if got := ev.Annotations[spanIDAnnotationKey]; got != sc.SpanID().String() {
+		t.Errorf("expected span ID annotation %q, got %q", sc.SpanID().String(), got)
+	}
+
+	// without a span in the context no annotations are added
+	req, _ = http.NewRequest("GET", "/api/v1/namespaces/default/pods", nil)
+	req = withTestContext(req, &user.DefaultInfo{Name: "admin"}, nil)
+	_, ev, _, err = createAuditEventAndAttachToContext(req, policyChecker)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := ev.Annotations[traceIDAnnotationKey]; ok {
+		t.Error("expected no trace ID annotation without a span in the request context")
+	}
+}
@@ -38,6 +38,7 @@ import (
 	"k8s.io/apiserver/pkg/endpoints/handlers/fieldmanager"
 	"k8s.io/apiserver/pkg/endpoints/handlers/finisher"
 	"k8s.io/apiserver/pkg/endpoints/handlers/negotiation"
+	"k8s.io/apiserver/pkg/endpoints/metrics"
 	"k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/apiserver/pkg/features"
 	"k8s.io/apiserver/pkg/registry/rest"
@@ -117,7 +118,11 @@ func createHandler(r rest.NamedCreater, scope *RequestScope, admit admission.Int
 		defaultGVK := scope.Kind
 		original := r.New()
 		trace.Step("About to convert to expected version")
-		obj, gvk, err := decoder.Decode(body, &defaultGVK, original)
+		var obj runtime.Object
+		var gvk *schema.GroupVersionKind
+		metrics.RecordDecodeAllocations(scope.Resource, func() {
+			obj, gvk, err = decoder.Decode(body, &defaultGVK, original)
+		})
 		if err != nil {
 			err = transformDecodeError(scope.Typer, err, original, gvk, body)
 			scope.err(err, w, req)
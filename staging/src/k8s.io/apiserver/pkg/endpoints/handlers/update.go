@@ -36,6 +36,7 @@ import (
 	"k8s.io/apiserver/pkg/endpoints/handlers/fieldmanager"
 	"k8s.io/apiserver/pkg/endpoints/handlers/finisher"
 	"k8s.io/apiserver/pkg/endpoints/handlers/negotiation"
+	"k8s.io/apiserver/pkg/endpoints/metrics"
 	"k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/apiserver/pkg/features"
 	"k8s.io/apiserver/pkg/registry/rest"
@@ -104,7 +105,11 @@ func UpdateResource(r rest.Updater, scope *RequestScope, admit admission.Interfa
 
 		trace.Step("About to convert to expected version")
 		decoder := scope.Serializer.DecoderToVersion(s.Serializer, scope.HubGroupVersion)
-		obj, gvk, err := decoder.Decode(body, &defaultGVK, original)
+		var obj runtime.Object
+		var gvk *schema.GroupVersionKind
+		metrics.RecordDecodeAllocations(scope.Resource, func() {
+			obj, gvk, err = decoder.Decode(body, &defaultGVK, original)
+		})
 		if err != nil {
 			err = transformDecodeError(scope.Typer, err, original, gvk, body)
 			scope.err(err, w, req)
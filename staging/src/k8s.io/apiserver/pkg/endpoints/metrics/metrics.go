@@ -19,16 +19,20 @@ package metrics
 import (
 	"bufio"
 	"context"
+	"mime"
 	"net"
 	"net/http"
 	"net/url"
+	runtimemetrics "runtime/metrics"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	restful "github.com/emicklei/go-restful"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	utilsets "k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apiserver/pkg/audit"
@@ -111,6 +115,16 @@ var (
 		},
 		[]string{"verb", "group", "version", "resource", "subresource", "scope", "component"},
 	)
+	decodeAllocations = compbasemetrics.NewHistogramVec(
+		&compbasemetrics.HistogramOpts{
+			Name: "apiserver_request_decode_allocated_bytes",
+			Help: "Sampled distribution of heap bytes allocated while decoding request bodies, by group, version and resource.",
+			// Use buckets ranging from 1000 bytes (1KB) to 10^9 bytes (1GB).
+			Buckets:        compbasemetrics.ExponentialBuckets(1000, 10.0, 7),
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"group", "version", "resource"},
+	)
 	// DroppedRequests is a number of requests dropped with 'Try again later' response"
 	DroppedRequests = compbasemetrics.NewCounterVec(
 		&compbasemetrics.CounterOpts{
@@ -191,6 +205,18 @@ var (
 		[]string{"verb", "resource", "subresource"},
 	)
 
+	// serializationFormats counts request and response bodies per serialization
+	// format so operators can track protobuf adoption and find clients stuck on
+	// JSON or YAML for hot paths.
+	serializationFormats = compbasemetrics.NewCounterVec(
+		&compbasemetrics.CounterOpts{
+			Name:           "apiserver_request_serialization_format_total",
+			Help:           "Number of request and response bodies processed per serialization format, broken out by direction and resource.",
+			StabilityLevel: compbasemetrics.ALPHA,
+		},
+		[]string{"direction", "resource", "format"},
+	)
+
 	requestFilterDuration = compbasemetrics.NewHistogramVec(
 		&compbasemetrics.HistogramOpts{
 			Name:           "apiserver_request_filter_duration_seconds",
@@ -237,6 +263,7 @@ var (
 		longRunningRequestGauge,
 		requestLatencies,
 		responseSizes,
+		decodeAllocations,
 		DroppedRequests,
 		TLSHandshakeErrors,
 		RegisteredWatchers,
@@ -246,6 +273,7 @@ var (
 		currentInqueueRequests,
 		requestTerminationsTotal,
 		apiSelfRequestCounter,
+		serializationFormats,
 		requestFilterDuration,
 		requestAbortsTotal,
 		requestPostTimeoutTotal,
@@ -355,6 +383,47 @@ func UpdateInflightRequestMetrics(phase string, nonmutating, mutating int) {
 	}
 }
 
+// decodeAllocationSampleRate is the fraction of decodes measured for the
+// apiserver_request_decode_allocated_bytes metric; even a cheap read of the
+// allocation counter is not worth doing on every request.
+const decodeAllocationSampleRate = 1000
+
+var decodeAllocationSampleCounter int64
+
+// heapAllocsMetricName is the monotonic count of heap bytes allocated. Unlike
+// runtime.ReadMemStats it can be read without a stop-the-world pause.
+const heapAllocsMetricName = "/gc/heap/allocs:bytes"
+
+// readHeapAllocatedBytes returns the cumulative heap bytes allocated, or
+// false if the runtime does not support the counter.
+func readHeapAllocatedBytes() (uint64, bool) {
+	sample := []runtimemetrics.Sample{{Name: heapAllocsMetricName}}
+	runtimemetrics.Read(sample)
+	if sample[0].Value.Kind() != runtimemetrics.KindUint64 {
+		return 0, false
+	}
+	return sample[0].Value.Uint64(), true
+}
+
+// RecordDecodeAllocations runs decode and, for a sampled subset of calls,
+// records the heap bytes allocated while it ran against the given resource.
+// Individual samples can be inflated by allocations from concurrent requests;
+// the distribution is meaningful in aggregate and is meant to surface
+// resources whose decoding and conversion generate excessive garbage.
+func RecordDecodeAllocations(gvr schema.GroupVersionResource, decode func()) {
+	if atomic.AddInt64(&decodeAllocationSampleCounter, 1)%decodeAllocationSampleRate != 0 {
+		decode()
+		return
+	}
+	before, ok := readHeapAllocatedBytes()
+	decode()
+	if !ok {
+		return
+	}
+	after, _ := readHeapAllocatedBytes()
+	decodeAllocations.WithLabelValues(gvr.Group, gvr.Version, gvr.Resource).Observe(float64(after - before))
+}
+
 func RecordFilterLatency(ctx context.Context, name string, elapsed time.Duration) {
 	requestFilterDuration.WithContext(ctx).WithLabelValues(name).Observe(elapsed.Seconds())
 }
@@ -458,6 +527,47 @@ func MonitorRequest(req *http.Request, verb, group, version, resource, subresour
 	}
 }
 
+// RecordSerializationFormats counts the serialization formats of the request
+// and response bodies of a resource request, so format adoption (e.g. protobuf
+// vs JSON) can be tracked per resource.
+func RecordSerializationFormats(req *http.Request, resource, responseContentType string) {
+	if len(resource) == 0 {
+		return
+	}
+	if req.ContentLength != 0 {
+		if format := cleanSerializationFormat(req.Header.Get("Content-Type")); len(format) > 0 {
+			serializationFormats.WithContext(req.Context()).WithLabelValues("request", resource, format).Inc()
+		}
+	}
+	if format := cleanSerializationFormat(responseContentType); len(format) > 0 {
+		serializationFormats.WithContext(req.Context()).WithLabelValues("response", resource, format).Inc()
+	}
+}
+
+// cleanSerializationFormat maps a MIME type to a low-cardinality format label,
+// folding the patch media types into the format they are expressed in.
+func cleanSerializationFormat(contentType string) string {
+	if len(contentType) == 0 {
+		return ""
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	switch mediaType {
+	case "application/json", "application/json-patch+json", "application/merge-patch+json", "application/strategic-merge-patch+json":
+		return "json"
+	case "application/yaml", "application/apply-patch+yaml":
+		return "yaml"
+	case "application/vnd.kubernetes.protobuf":
+		return "protobuf"
+	case "application/cbor":
+		return "cbor"
+	default:
+		return "other"
+	}
+}
+
 // InstrumentRouteFunc works like Prometheus' InstrumentHandlerFunc but wraps
 // the go-restful RouteFunction instead of a HandlerFunc plus some Kubernetes endpoint specific information.
 func InstrumentRouteFunc(verb, group, version, resource, subresource, scope, component string, deprecated bool, removedRelease string, routeFunc restful.RouteFunction) restful.RouteFunction {
@@ -483,6 +593,7 @@ func InstrumentRouteFunc(verb, group, version, resource, subresource, scope, com
 
 		routeFunc(req, response)
 
+		RecordSerializationFormats(req.Request, resource, delegate.Header().Get("Content-Type"))
 		MonitorRequest(req.Request, verb, group, version, resource, subresource, scope, component, deprecated, removedRelease, delegate.Status(), delegate.ContentLength(), time.Since(requestReceivedTimestamp))
 	})
 }
@@ -508,6 +619,7 @@ func InstrumentHandlerFunc(verb, group, version, resource, subresource, scope, c
 
 		handler(w, req)
 
+		RecordSerializationFormats(req, resource, delegate.Header().Get("Content-Type"))
 		MonitorRequest(req, verb, group, version, resource, subresource, scope, component, deprecated, removedRelease, delegate.Status(), delegate.ContentLength(), time.Since(requestReceivedTimestamp))
 	}
 }
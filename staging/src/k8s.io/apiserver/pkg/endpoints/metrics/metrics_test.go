@@ -158,3 +158,31 @@ func TestCleanScope(t *testing.T) {
 		})
 	}
 }
+
+func TestCleanSerializationFormat(t *testing.T) {
+	testCases := []struct {
+		contentType    string
+		expectedFormat string
+	}{
+		{"", ""},
+		{"application/json", "json"},
+		{"application/json; charset=utf-8", "json"},
+		{"application/json-patch+json", "json"},
+		{"application/merge-patch+json", "json"},
+		{"application/strategic-merge-patch+json", "json"},
+		{"application/yaml", "yaml"},
+		{"application/apply-patch+yaml", "yaml"},
+		{"application/vnd.kubernetes.protobuf", "protobuf"},
+		{"application/cbor", "cbor"},
+		{"text/plain", "other"},
+		{"not a media type", ""},
+	}
+
+	for _, test := range testCases {
+		t.Run(test.contentType, func(t *testing.T) {
+			if format := cleanSerializationFormat(test.contentType); format != test.expectedFormat {
+				t.Errorf("expected format %q for %q, got %q", test.expectedFormat, test.contentType, format)
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generic
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/admission"
+	quota "k8s.io/apiserver/pkg/quota/v1"
+)
+
+// usageFuncEvaluator provides an implementation for quota.Evaluator that
+// delegates usage calculation to a caller-provided function. It lets
+// components plug custom usage calculators for a group resource into a
+// quota.Registry without implementing the full Evaluator interface.
+type usageFuncEvaluator struct {
+	// GroupResource that this evaluator tracks.
+	groupResource schema.GroupResource
+	// A function that knows how to list resources by namespace.
+	listFuncByNamespace ListFuncByNamespace
+	// Names this evaluator matches in quota documents.
+	resourceNames []corev1.ResourceName
+	// A function that computes the usage of a single object.
+	usageFunc UsageFunc
+}
+
+// Constraints returns an error if the configured resource name is not in the required set.
+func (u *usageFuncEvaluator) Constraints(required []corev1.ResourceName, item runtime.Object) error {
+	// custom usage functions have no required fields
+	return nil
+}
+
+// Handles returns true if this evaluator needs to track these attributes.
+func (u *usageFuncEvaluator) Handles(a admission.Attributes) bool {
+	// usage computed from object content can change on update as well as create
+	operation := a.GetOperation()
+	return operation == admission.Create || operation == admission.Update
+}
+
+// Matches returns true if the evaluator matches the specified quota with the provided input item
+func (u *usageFuncEvaluator) Matches(resourceQuota *corev1.ResourceQuota, item runtime.Object) (bool, error) {
+	return Matches(resourceQuota, item, u.MatchingResources, MatchesNoScopeFunc)
+}
+
+// MatchingResources takes the input specified list of resources and returns the set of resources it matches.
+func (u *usageFuncEvaluator) MatchingResources(input []corev1.ResourceName) []corev1.ResourceName {
+	return quota.Intersection(input, u.resourceNames)
+}
+
+// MatchingScopes takes the input specified list of scopes and input object. Returns the set of scopes resource matches.
+func (u *usageFuncEvaluator) MatchingScopes(item runtime.Object, scopes []corev1.ScopedResourceSelectorRequirement) ([]corev1.ScopedResourceSelectorRequirement, error) {
+	return []corev1.ScopedResourceSelectorRequirement{}, nil
+}
+
+// UncoveredQuotaScopes takes the input matched scopes which are limited by configuration and the matched quota scopes.
+// It returns the scopes which are in limited scopes but don't have a corresponding covering quota scope
+func (u *usageFuncEvaluator) UncoveredQuotaScopes(limitedScopes []corev1.ScopedResourceSelectorRequirement, matchedQuotaScopes []corev1.ScopedResourceSelectorRequirement) ([]corev1.ScopedResourceSelectorRequirement, error) {
+	return []corev1.ScopedResourceSelectorRequirement{}, nil
+}
+
+// Usage returns the resource usage for the specified object
+func (u *usageFuncEvaluator) Usage(object runtime.Object) (corev1.ResourceList, error) {
+	return u.usageFunc(object)
+}
+
+// GroupResource tracked by this evaluator
+func (u *usageFuncEvaluator) GroupResource() schema.GroupResource {
+	return u.groupResource
+}
+
+// UsageStats calculates aggregate usage for the object.
+func (u *usageFuncEvaluator) UsageStats(options quota.UsageStatsOptions) (quota.UsageStats, error) {
+	return CalculateUsageStats(options, u.listFuncByNamespace, MatchesNoScopeFunc, u.Usage)
+}
+
+// Verify implementation of interface at compile time.
+var _ quota.Evaluator = &usageFuncEvaluator{}
+
+// NewUsageFuncEvaluator returns an evaluator for the specified group resource
+// that computes usage with the provided usage function. The evaluator matches
+// the provided resource names in quota documents. Register the result with a
+// quota.Registry to make the calculator take effect.
+func NewUsageFuncEvaluator(
+	groupResource schema.GroupResource, listFuncByNamespace ListFuncByNamespace,
+	resourceNames []corev1.ResourceName, usageFunc UsageFunc) quota.Evaluator {
+
+	return &usageFuncEvaluator{
+		groupResource:       groupResource,
+		listFuncByNamespace: listFuncByNamespace,
+		resourceNames:       resourceNames,
+		usageFunc:           usageFunc,
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generic
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	quota "k8s.io/apiserver/pkg/quota/v1"
+)
+
+func TestUsageFuncEvaluator(t *testing.T) {
+	countName := corev1.ResourceName("count/widgets.example.com")
+	evaluator := NewUsageFuncEvaluator(
+		schema.GroupResource{Group: "example.com", Resource: "widgets"},
+		func(namespace string) ([]runtime.Object, error) {
+			return []runtime.Object{
+				&corev1.ConfigMap{},
+				&corev1.ConfigMap{},
+			}, nil
+		},
+		[]corev1.ResourceName{countName},
+		func(object runtime.Object) (corev1.ResourceList, error) {
+			return corev1.ResourceList{countName: resource.MustParse("1")}, nil
+		},
+	)
+
+	// Usage delegates to the provided usage function
+	used, err := evaluator.Usage(&corev1.ConfigMap{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := (corev1.ResourceList{countName: resource.MustParse("1")}); !quota.Equals(expected, used) {
+		t.Errorf("expected usage %v, got %v", expected, used)
+	}
+
+	// UsageStats aggregates usage over the listed objects
+	stats, err := evaluator.UsageStats(quota.UsageStatsOptions{
+		Namespace: "test",
+		Resources: []corev1.ResourceName{countName},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := (corev1.ResourceList{countName: resource.MustParse("2")}); !quota.Equals(expected, stats.Used) {
+		t.Errorf("expected aggregate usage %v, got %v", expected, stats.Used)
+	}
+
+	// MatchingResources intersects the input with the configured names
+	matching := evaluator.MatchingResources([]corev1.ResourceName{countName, corev1.ResourcePods})
+	if expected := []corev1.ResourceName{countName}; !reflect.DeepEqual(expected, matching) {
+		t.Errorf("expected matching resources %v, got %v", expected, matching)
+	}
+	if matching := evaluator.MatchingResources([]corev1.ResourceName{corev1.ResourcePods}); len(matching) != 0 {
+		t.Errorf("expected no matching resources, got %v", matching)
+	}
+}
@@ -66,10 +66,8 @@ func Max(a corev1.ResourceList, b corev1.ResourceList) corev1.ResourceList {
 	result := corev1.ResourceList{}
 	for key, value := range a {
 		if other, found := b[key]; found {
-			if value.Cmp(other) <= 0 {
-				result[key] = other.DeepCopy()
-				continue
-			}
+			result[key] = resource.MaxQuantity(value, other)
+			continue
 		}
 		result[key] = value.DeepCopy()
 	}
@@ -110,11 +108,7 @@ func SubtractWithNonNegativeResult(a corev1.ResourceList, b corev1.ResourceList)
 		if other, found := b[key]; found {
 			quantity.Sub(other)
 		}
-		if quantity.Cmp(zero) > 0 {
-			result[key] = quantity
-		} else {
-			result[key] = zero
-		}
+		result[key] = resource.MaxQuantity(quantity, zero)
 	}
 
 	for key := range b {
@@ -195,6 +195,13 @@ type Config struct {
 	// but /readyz will return failure.
 	ShutdownDelayDuration time.Duration
 
+	// ShutdownStreamingGracePeriod, if non-zero, bounds how long in-flight
+	// long-running requests (watches and other streaming connections) may keep
+	// running after shutdown has been initiated. Once the grace period expires
+	// their request contexts are canceled. Zero leaves streaming connections
+	// to terminate on their own.
+	ShutdownStreamingGracePeriod time.Duration
+
 	// The limit on the total size increase all "copy" operations in a json
 	// patch may cause.
 	// This affects all places that applies json patch in the binary.
@@ -778,6 +785,9 @@ func DefaultBuildHandlerChain(apiHandler http.Handler, c *Config) http.Handler {
 	handler = genericapifilters.WithRequestDeadline(handler, c.AuditBackend, c.AuditPolicyChecker,
 		c.LongRunningFunc, c.Serializer, c.RequestTimeout)
 	handler = genericfilters.WithWaitGroup(handler, c.LongRunningFunc, c.HandlerChainWaitGroup)
+	if c.ShutdownStreamingGracePeriod > 0 {
+		handler = genericfilters.WithShutdownStreamingTermination(handler, c.LongRunningFunc, c.lifecycleSignals.ShutdownInitiated.Signaled(), c.ShutdownStreamingGracePeriod)
+	}
 	if c.SecureServing != nil && !c.SecureServing.DisableHTTP2 && c.GoawayChance > 0 {
 		handler = genericfilters.WithProbabilisticGoaway(handler, c.GoawayChance)
 	}
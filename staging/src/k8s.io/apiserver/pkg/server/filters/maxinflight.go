@@ -37,6 +37,20 @@ const (
 	// TODO: maybe make this dynamic? or user-adjustable?
 	retryAfter = "1"
 
+	// ResponseHeaderRetryReason names the response header carrying a
+	// machine-readable reason code for a 429 response, so clients can tell
+	// apart the different throttling mechanisms without parsing the body.
+	ResponseHeaderRetryReason = "X-Kubernetes-Retry-Reason"
+
+	// RetryReasonMaxInflightExceeded indicates the request was rejected by the
+	// simple max-in-flight limiter.
+	RetryReasonMaxInflightExceeded = "MaxInflightExceeded"
+
+	// RetryReasonPriorityAndFairness indicates the request was rejected by
+	// API Priority and Fairness (queue full, queue wait timeout, or
+	// concurrency limit).
+	RetryReasonPriorityAndFairness = "PriorityAndFairnessRejected"
+
 	// How often inflight usage metric should be updated. Because
 	// the metrics tracks maximal value over period making this
 	// longer will increase the metric value.
@@ -204,7 +218,7 @@ func WithMaxInFlightLimit(
 					metrics.DroppedRequests.WithContext(ctx).WithLabelValues(metrics.ReadOnlyKind).Inc()
 				}
 				metrics.RecordRequestTermination(r, requestInfo, metrics.APIServerComponent, http.StatusTooManyRequests)
-				tooManyRequests(r, w)
+				tooManyRequests(r, w, RetryReasonMaxInflightExceeded)
 			}
 		}
 	})
@@ -216,8 +230,9 @@ func StartMaxInFlightWatermarkMaintenance(stopCh <-chan struct{}) {
 	startWatermarkMaintenance(watermark, stopCh)
 }
 
-func tooManyRequests(req *http.Request, w http.ResponseWriter) {
+func tooManyRequests(req *http.Request, w http.ResponseWriter, reason string) {
 	// Return a 429 status indicating "Too Many Requests"
 	w.Header().Set("Retry-After", retryAfter)
+	w.Header().Set(ResponseHeaderRetryReason, reason)
 	http.Error(w, "Too many requests, please try again later.", http.StatusTooManyRequests)
 }
@@ -318,3 +318,22 @@ func TestMaxInFlightSkipsMasters(t *testing.T) {
 
 	responses.Wait()
 }
+
+func TestTooManyRequestsRetryReasonHeader(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/pods", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	tooManyRequests(req, w, RetryReasonMaxInflightExceeded)
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected %d, got %d", http.StatusTooManyRequests, w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != retryAfter {
+		t.Errorf("expected Retry-After %q, got %q", retryAfter, got)
+	}
+	if got := w.Header().Get(ResponseHeaderRetryReason); got != RetryReasonMaxInflightExceeded {
+		t.Errorf("expected %s %q, got %q", ResponseHeaderRetryReason, RetryReasonMaxInflightExceeded, got)
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filters
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+)
+
+// WithShutdownStreamingTermination cancels the context of in-flight
+// long-running requests (watch, exec, attach, port-forward and other
+// streaming connections) gracePeriod after the server has been asked to
+// shut down. Without this, streaming connections held open by clients keep
+// their handlers running until the client goes away, well past
+// ShutdownTimeout. Handlers that honor context cancellation terminate the
+// stream cleanly; the grace period gives well-behaved clients time to
+// re-establish their connections elsewhere first.
+//
+// Non long-running requests pass through unmodified, their lifetime is
+// already bounded by the request timeout and the shutdown wait group.
+func WithShutdownStreamingTermination(handler http.Handler, longRunning apirequest.LongRunningRequestCheck, shutdownInitiatedCh <-chan struct{}, gracePeriod time.Duration) http.Handler {
+	if longRunning == nil || shutdownInitiatedCh == nil {
+		return handler
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		ctx := req.Context()
+		requestInfo, ok := apirequest.RequestInfoFrom(ctx)
+		if !ok || !longRunning(req, requestInfo) {
+			handler.ServeHTTP(w, req)
+			return
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			select {
+			case <-ctx.Done():
+				// the request finished on its own, nothing to do.
+				return
+			case <-shutdownInitiatedCh:
+			}
+
+			t := time.NewTimer(gracePeriod)
+			defer t.Stop()
+			select {
+			case <-ctx.Done():
+			case <-t.C:
+				cancel()
+			}
+		}()
+
+		handler.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
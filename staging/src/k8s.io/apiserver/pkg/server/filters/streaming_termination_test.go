@@ -0,0 +1,88 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filters
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apirequest "k8s.io/apiserver/pkg/endpoints/request"
+)
+
+func TestWithShutdownStreamingTermination(t *testing.T) {
+	tests := []struct {
+		name           string
+		longRunning    bool
+		signalShutdown bool
+		wantCanceled   bool
+	}{
+		{
+			name:           "long-running request is canceled after shutdown",
+			longRunning:    true,
+			signalShutdown: true,
+			wantCanceled:   true,
+		},
+		{
+			name:           "long-running request is left alone before shutdown",
+			longRunning:    true,
+			signalShutdown: false,
+			wantCanceled:   false,
+		},
+		{
+			name:           "non long-running request is not canceled",
+			longRunning:    false,
+			signalShutdown: true,
+			wantCanceled:   false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			shutdownCh := make(chan struct{})
+			if test.signalShutdown {
+				close(shutdownCh)
+			}
+			longRunningCheck := func(r *http.Request, requestInfo *apirequest.RequestInfo) bool {
+				return test.longRunning
+			}
+
+			canceled := false
+			inner := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				select {
+				case <-req.Context().Done():
+					canceled = true
+				case <-time.After(500 * time.Millisecond):
+				}
+			})
+			handler := WithShutdownStreamingTermination(inner, longRunningCheck, shutdownCh, time.Millisecond)
+
+			req, err := http.NewRequest(http.MethodGet, "/api/v1/namespaces/default/pods?watch=true", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req = req.WithContext(apirequest.WithRequestInfo(req.Context(), &apirequest.RequestInfo{Verb: "watch"}))
+
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if canceled != test.wantCanceled {
+				t.Errorf("expected canceled=%v, got %v", test.wantCanceled, canceled)
+			}
+		})
+	}
+}
@@ -70,6 +70,10 @@ type AuditOptions struct {
 	// If unspecified, a default is provided.
 	PolicyFile string
 
+	// AnnotationLimits bounds the annotations recorded on a single audit
+	// event. Zero limits disable enforcement.
+	AnnotationLimits audit.AnnotationLimits
+
 	// Plugin options
 	LogOptions     AuditLogOptions
 	WebhookOptions AuditWebhookOptions
@@ -193,6 +197,12 @@ func (o *AuditOptions) Validate() []error {
 	}
 
 	var allErrors []error
+	if o.AnnotationLimits.MaxCount < 0 {
+		allErrors = append(allErrors, fmt.Errorf("invalid audit annotations max count %v, must not be negative", o.AnnotationLimits.MaxCount))
+	}
+	if o.AnnotationLimits.MaxValueLength < 0 {
+		allErrors = append(allErrors, fmt.Errorf("invalid audit annotations max value length %v, must not be negative", o.AnnotationLimits.MaxValueLength))
+	}
 	allErrors = append(allErrors, o.LogOptions.Validate()...)
 	allErrors = append(allErrors, o.WebhookOptions.Validate()...)
 
@@ -231,6 +241,9 @@ func validateBackendBatchOptions(pluginName string, options AuditBatchOptions) e
 			return fmt.Errorf("invalid audit batch %s throttle burst %v, must be a positive number", pluginName, config.ThrottleBurst)
 		}
 	}
+	if config.MaxBackpressureWait < 0 {
+		return fmt.Errorf("invalid audit batch %s max backpressure wait %v, must not be negative", pluginName, config.MaxBackpressureWait)
+	}
 	return nil
 }
 
@@ -270,6 +283,12 @@ func (o *AuditOptions) AddFlags(fs *pflag.FlagSet) {
 
 	fs.StringVar(&o.PolicyFile, "audit-policy-file", o.PolicyFile,
 		"Path to the file that defines the audit policy configuration.")
+	fs.IntVar(&o.AnnotationLimits.MaxCount, "audit-annotations-max-count", o.AnnotationLimits.MaxCount,
+		"The maximum number of annotations recorded on a single audit event. Annotations set "+
+			"beyond the limit are dropped and the event is marked truncated. Zero disables the limit.")
+	fs.IntVar(&o.AnnotationLimits.MaxValueLength, "audit-annotations-max-value-length", o.AnnotationLimits.MaxValueLength,
+		"The maximum length of a single audit event annotation value. Longer values are truncated "+
+			"and the event is marked truncated. Zero disables the limit.")
 
 	o.LogOptions.AddFlags(fs)
 	o.LogOptions.BatchOptions.AddFlags(pluginlog.PluginName, fs)
@@ -289,6 +308,8 @@ func (o *AuditOptions) ApplyTo(
 		return fmt.Errorf("server config must be non-nil")
 	}
 
+	audit.SetAnnotationLimits(o.AnnotationLimits)
+
 	// 1. Build policy checker
 	checker, err := o.newPolicyChecker()
 	if err != nil {
@@ -388,6 +409,10 @@ func (o *AuditBatchOptions) AddFlags(pluginName string, fs *pflag.FlagSet) {
 	fs.IntVar(&o.BatchConfig.ThrottleBurst, fmt.Sprintf("audit-%s-batch-throttle-burst", pluginName),
 		o.BatchConfig.ThrottleBurst, "Maximum number of requests sent at the same "+
 			"moment if ThrottleQPS was not utilized before. Only used in batch mode.")
+	fs.DurationVar(&o.BatchConfig.MaxBackpressureWait, fmt.Sprintf("audit-%s-batch-max-backpressure-wait", pluginName),
+		o.BatchConfig.MaxBackpressureWait, "The maximum amount of time a request is delayed waiting "+
+			"for buffer space when the audit buffer is full, instead of dropping its events. "+
+			"Zero disables backpressure and drops events immediately. Only used in batch mode.")
 }
 
 type ignoreErrorsBackend struct {
@@ -0,0 +1,105 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"net/http"
+	"net/url"
+
+	utilnet "k8s.io/apimachinery/pkg/util/net"
+	compbasemetrics "k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+	"k8s.io/klog/v2"
+)
+
+const (
+	retryBackendSame      = "same"
+	retryBackendAlternate = "alternate"
+)
+
+var proxyConnectionRetries = compbasemetrics.NewCounterVec(
+	&compbasemetrics.CounterOpts{
+		Name:           "apiserver_proxy_connection_retries_total",
+		Help:           "Number of proxied requests retried after a connection reset or refused error from the backend, broken out by whether the retry targeted the same or an alternate backend.",
+		StabilityLevel: compbasemetrics.ALPHA,
+	},
+	[]string{"backend"},
+)
+
+func init() {
+	legacyregistry.MustRegister(proxyConnectionRetries)
+}
+
+// NewRetryingRoundTripper returns a round tripper that retries a request once
+// when the backend resets or refuses the connection, smoothing over backend
+// restarts. Only GET and HEAD requests without a body are retried, since those
+// are idempotent and can be replayed safely. If alternateBackend is non-nil it
+// is consulted for a fresh backend location before retrying, so callers with
+// multiple backend endpoints (e.g. the service proxy) can steer the retry to a
+// different endpoint; otherwise the retry goes to the original backend.
+func NewRetryingRoundTripper(delegate http.RoundTripper, alternateBackend func() (*url.URL, error)) http.RoundTripper {
+	return &retryRoundTripper{delegate: delegate, alternateBackend: alternateBackend}
+}
+
+type retryRoundTripper struct {
+	delegate         http.RoundTripper
+	alternateBackend func() (*url.URL, error)
+}
+
+func (rt *retryRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	delegate := rt.delegate
+	if delegate == nil {
+		delegate = http.DefaultTransport
+	}
+
+	resp, err := delegate.RoundTrip(req)
+	if err == nil || !isRetriable(req, err) {
+		return resp, err
+	}
+
+	backend := retryBackendSame
+	retryReq := utilnet.CloneRequest(req)
+	if rt.alternateBackend != nil {
+		location, altErr := rt.alternateBackend()
+		if altErr != nil {
+			klog.V(4).Infof("Not retrying request to %s after %v, no alternate backend available: %v", req.URL.Host, err, altErr)
+			return resp, err
+		}
+		retryReq.URL.Scheme = location.Scheme
+		retryReq.URL.Host = location.Host
+		if location.Host != req.URL.Host {
+			backend = retryBackendAlternate
+		}
+	}
+
+	klog.V(4).Infof("Retrying %s request to %s after %v", req.Method, retryReq.URL.Host, err)
+	proxyConnectionRetries.WithLabelValues(backend).Inc()
+	return delegate.RoundTrip(retryReq)
+}
+
+// isRetriable returns true if the failed request is safe to replay: it must
+// be an idempotent bodyless method, and the error must indicate the backend
+// never processed the request.
+func isRetriable(req *http.Request, err error) bool {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return false
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		return false
+	}
+	return utilnet.IsConnectionReset(err) || utilnet.IsConnectionRefused(err)
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package proxy
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"syscall"
+	"testing"
+)
+
+type fakeRoundTripper struct {
+	errs  []error
+	hosts []string
+}
+
+func (rt *fakeRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	rt.hosts = append(rt.hosts, req.URL.Host)
+	if len(rt.errs) > 0 {
+		err := rt.errs[0]
+		rt.errs = rt.errs[1:]
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &http.Response{StatusCode: http.StatusOK}, nil
+}
+
+func TestRetryingRoundTripper(t *testing.T) {
+	tests := []struct {
+		name             string
+		method           string
+		errs             []error
+		alternateBackend func() (*url.URL, error)
+		expectedHosts    []string
+		expectError      bool
+	}{
+		{
+			name:          "success is not retried",
+			method:        "GET",
+			expectedHosts: []string{"backend-1:80"},
+		},
+		{
+			name:          "connection reset is retried against the same backend",
+			method:        "GET",
+			errs:          []error{syscall.ECONNRESET},
+			expectedHosts: []string{"backend-1:80", "backend-1:80"},
+		},
+		{
+			name:          "connection refused is retried for HEAD",
+			method:        "HEAD",
+			errs:          []error{syscall.ECONNREFUSED},
+			expectedHosts: []string{"backend-1:80", "backend-1:80"},
+		},
+		{
+			name:   "retry targets the alternate backend",
+			method: "GET",
+			errs:   []error{syscall.ECONNRESET},
+			alternateBackend: func() (*url.URL, error) {
+				return &url.URL{Scheme: "http", Host: "backend-2:80"}, nil
+			},
+			expectedHosts: []string{"backend-1:80", "backend-2:80"},
+		},
+		{
+			name:   "alternate backend errors are not retried",
+			method: "GET",
+			errs:   []error{syscall.ECONNRESET},
+			alternateBackend: func() (*url.URL, error) {
+				return nil, errors.New("no endpoints available")
+			},
+			expectedHosts: []string{"backend-1:80"},
+			expectError:   true,
+		},
+		{
+			name:          "non-idempotent methods are not retried",
+			method:        "POST",
+			errs:          []error{syscall.ECONNRESET},
+			expectedHosts: []string{"backend-1:80"},
+			expectError:   true,
+		},
+		{
+			name:          "other errors are not retried",
+			method:        "GET",
+			errs:          []error{errors.New("timeout")},
+			expectedHosts: []string{"backend-1:80"},
+			expectError:   true,
+		},
+		{
+			name:          "only one retry is attempted",
+			method:        "GET",
+			errs:          []error{syscall.ECONNRESET, syscall.ECONNRESET},
+			expectedHosts: []string{"backend-1:80", "backend-1:80"},
+			expectError:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeRoundTripper{errs: tt.errs}
+			rt := NewRetryingRoundTripper(fake, tt.alternateBackend)
+			req, err := http.NewRequest(tt.method, "http://backend-1:80/healthz", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			resp, err := rt.RoundTrip(req)
+			if tt.expectError != (err != nil) {
+				t.Fatalf("expected error %v, got %v", tt.expectError, err)
+			}
+			if !tt.expectError && resp.StatusCode != http.StatusOK {
+				t.Errorf("unexpected status code %d", resp.StatusCode)
+			}
+			if len(fake.hosts) != len(tt.expectedHosts) {
+				t.Fatalf("expected requests to %v, got %v", tt.expectedHosts, fake.hosts)
+			}
+			for i := range fake.hosts {
+				if fake.hosts[i] != tt.expectedHosts[i] {
+					t.Errorf("expected request %d to go to %q, got %q", i, tt.expectedHosts[i], fake.hosts[i])
+				}
+			}
+		})
+	}
+}
@@ -270,3 +270,61 @@ func TestVersionedConn(t *testing.T) {
 		}()
 	}
 }
+
+func TestRequireSameOrigin(t *testing.T) {
+	tests := []struct {
+		origin    string
+		host      string
+		expectErr bool
+	}{
+		{origin: "", host: "example.com", expectErr: false},
+		{origin: "https://example.com", host: "example.com", expectErr: false},
+		{origin: "https://EXAMPLE.com", host: "example.com", expectErr: false},
+		{origin: "https://example.com:8443", host: "example.com:8443", expectErr: false},
+		{origin: "https://attacker.com", host: "example.com", expectErr: true},
+		{origin: "https://example.com.attacker.com", host: "example.com", expectErr: true},
+		{origin: "://%%%", host: "example.com", expectErr: true},
+	}
+	for _, test := range tests {
+		req, err := http.NewRequest("GET", "https://"+test.host+"/api", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Host = test.host
+		if len(test.origin) > 0 {
+			req.Header.Set("Origin", test.origin)
+		}
+		err = RequireSameOrigin(req)
+		if test.expectErr != (err != nil) {
+			t.Errorf("origin %q host %q: expected error %v, got %v", test.origin, test.host, test.expectErr, err)
+		}
+	}
+}
+
+func TestOriginAllowlist(t *testing.T) {
+	check := OriginAllowlist([]string{"https://ui.example.com"})
+	tests := []struct {
+		origin    string
+		expectErr bool
+	}{
+		{origin: "", expectErr: false},
+		{origin: "https://example.com", expectErr: false},
+		{origin: "https://ui.example.com", expectErr: false},
+		{origin: "https://UI.EXAMPLE.com", expectErr: false},
+		{origin: "https://attacker.com", expectErr: true},
+	}
+	for _, test := range tests {
+		req, err := http.NewRequest("GET", "https://example.com/api", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Host = "example.com"
+		if len(test.origin) > 0 {
+			req.Header.Set("Origin", test.origin)
+		}
+		err = check(req)
+		if test.expectErr != (err != nil) {
+			t.Errorf("origin %q: expected error %v, got %v", test.origin, test.expectErr, err)
+		}
+	}
+}
@@ -50,6 +50,11 @@ type BatchConfig struct {
 
 	// Whether the delegate backend should be called asynchronously.
 	AsyncDelegate bool
+
+	// MaxBackpressureWait bounds how long ProcessEvents waits for buffer space
+	// before dropping events when the buffer is full. Zero means events are
+	// dropped immediately (the historical behavior).
+	MaxBackpressureWait time.Duration
 }
 
 type bufferedBackend struct {
@@ -80,6 +85,9 @@ type bufferedBackend struct {
 
 	// Limits the number of batches sent to the delegate backend per second.
 	throttle flowcontrol.RateLimiter
+
+	// Maximum amount of time to wait for buffer space before dropping an event.
+	maxBackpressureWait time.Duration
 }
 
 var _ audit.Backend = &bufferedBackend{}
@@ -100,6 +108,8 @@ func NewBackend(delegate audit.Backend, config BatchConfig) audit.Backend {
 		shutdownCh:      make(chan struct{}),
 		wg:              sync.WaitGroup{},
 		throttle:        throttle,
+
+		maxBackpressureWait: config.MaxBackpressureWait,
 	}
 }
 
@@ -278,13 +288,35 @@ func (b *bufferedBackend) ProcessEvents(ev ...*auditinternal.Event) bool {
 		select {
 		case b.buffer <- event:
 		default:
-			sendErr = fmt.Errorf("audit buffer queue blocked")
-			return true
+			if !b.waitForBufferSpace(event) {
+				sendErr = fmt.Errorf("audit buffer queue blocked")
+				return true
+			}
 		}
 	}
 	return true
 }
 
+// waitForBufferSpace makes a bounded attempt to enqueue the event once the
+// buffer has filled, delaying the caller instead of dropping the event. It
+// returns false if the event could not be enqueued within the configured
+// backpressure window.
+func (b *bufferedBackend) waitForBufferSpace(event *auditinternal.Event) bool {
+	if b.maxBackpressureWait <= 0 {
+		return false
+	}
+	t := time.NewTimer(b.maxBackpressureWait)
+	defer t.Stop()
+	select {
+	case b.buffer <- event:
+		return true
+	case <-t.C:
+		return false
+	case <-b.shutdownCh:
+		return false
+	}
+}
+
 func (b *bufferedBackend) String() string {
 	return fmt.Sprintf("%s<%s>", PluginName, b.delegateBackend)
 }
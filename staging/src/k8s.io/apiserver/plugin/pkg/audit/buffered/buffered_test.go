@@ -157,6 +157,46 @@ func TestBufferedBackendProcessEventsBufferFull(t *testing.T) {
 	require.Len(t, backend.buffer, 1, "buffed contains more elements than it should")
 }
 
+func TestBufferedBackendProcessEventsBackpressure(t *testing.T) {
+	t.Parallel()
+
+	config := testBatchConfig()
+	config.BufferSize = 1
+	config.MaxBackpressureWait = wait.ForeverTestTimeout
+	backend := NewBackend(&fake.Backend{}, config).(*bufferedBackend)
+
+	backend.ProcessEvents(newEvents(1)...)
+
+	// free buffer space once the second call is blocked waiting for it
+	drained := make(chan struct{})
+	go func() {
+		defer close(drained)
+		<-backend.buffer
+	}()
+
+	backend.ProcessEvents(newEvents(1)...)
+
+	select {
+	case <-drained:
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatal("timed out waiting for the buffer to drain")
+	}
+	require.Len(t, backend.buffer, 1, "expected the delayed event to be enqueued")
+}
+
+func TestBufferedBackendProcessEventsBackpressureTimeout(t *testing.T) {
+	t.Parallel()
+
+	config := testBatchConfig()
+	config.BufferSize = 1
+	config.MaxBackpressureWait = 10 * time.Millisecond
+	backend := NewBackend(&fake.Backend{}, config).(*bufferedBackend)
+
+	backend.ProcessEvents(newEvents(2)...)
+
+	require.Len(t, backend.buffer, 1, "expected the event to be dropped after the backpressure window")
+}
+
 func TestBufferedBackendShutdownWaitsForDelegatedCalls(t *testing.T) {
 	t.Parallel()
 
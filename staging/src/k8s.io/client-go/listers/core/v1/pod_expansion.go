@@ -0,0 +1,68 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+// PodNodeNameIndex is the name of the index that indexes Pods by their
+// spec.nodeName. Register it with AddPodNodeNameIndexer before calling
+// ListByNodeName.
+const PodNodeNameIndex = "spec.nodeName"
+
+// AddPodNodeNameIndexer registers the pods-by-node index with the given
+// indexer, typically the pod informer's indexer.
+func AddPodNodeNameIndexer(indexer cache.Indexer) error {
+	return indexer.AddIndexers(cache.Indexers{
+		PodNodeNameIndex: func(obj interface{}) ([]string, error) {
+			pod, ok := obj.(*v1.Pod)
+			if !ok || len(pod.Spec.NodeName) == 0 {
+				return nil, nil
+			}
+			return []string{pod.Spec.NodeName}, nil
+		},
+	})
+}
+
+// PodListerExpansion allows custom methods to be added to
+// PodLister.
+type PodListerExpansion interface {
+	// ListByNodeName lists pods across all namespaces bound to the given
+	// node using the PodNodeNameIndex index, which must have been
+	// registered with AddPodNodeNameIndexer.
+	// Objects returned here must be treated as read-only.
+	ListByNodeName(nodeName string) ([]*v1.Pod, error)
+}
+
+// PodNamespaceListerExpansion allows custom methods to be added to
+// PodNamespaceLister.
+type PodNamespaceListerExpansion interface{}
+
+// ListByNodeName lists pods across all namespaces bound to the given node.
+func (s *podLister) ListByNodeName(nodeName string) ([]*v1.Pod, error) {
+	objs, err := s.indexer.ByIndex(PodNodeNameIndex, nodeName)
+	if err != nil {
+		return nil, err
+	}
+	pods := make([]*v1.Pod, 0, len(objs))
+	for _, obj := range objs {
+		pods = append(pods, obj.(*v1.Pod))
+	}
+	return pods, nil
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"testing"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+)
+
+func TestPodListerListByNodeName(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	if err := AddPodNodeNameIndexer(indexer); err != nil {
+		t.Fatal(err)
+	}
+	lister := NewPodLister(indexer)
+
+	pod := func(namespace, name, nodeName string) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+			Spec:       v1.PodSpec{NodeName: nodeName},
+		}
+	}
+	for _, p := range []*v1.Pod{
+		pod("ns1", "scheduled-1", "node-1"),
+		pod("ns2", "scheduled-2", "node-1"),
+		pod("ns1", "other-node", "node-2"),
+		pod("ns1", "unscheduled", ""),
+	} {
+		if err := indexer.Add(p); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pods, err := lister.ListByNodeName("node-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := sets.NewString()
+	for _, p := range pods {
+		names.Insert(p.Namespace + "/" + p.Name)
+	}
+	if expected := sets.NewString("ns1/scheduled-1", "ns2/scheduled-2"); !names.Equal(expected) {
+		t.Errorf("expected pods %v, got %v", expected.List(), names.List())
+	}
+
+	pods, err = lister.ListByNodeName("node-3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pods) != 0 {
+		t.Errorf("expected no pods on node-3, got %v", pods)
+	}
+}
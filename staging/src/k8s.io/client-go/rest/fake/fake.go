@@ -62,6 +62,10 @@ type RESTClient struct {
 	Client *http.Client
 	// Resp is returned to the caller after Req is recorded, unless Err or Client are set.
 	Resp *http.Response
+	// BaseURL, if set, is used as the base URL for requests built by this client
+	// instead of https://localhost. This is mainly useful when Client points at a
+	// real server, e.g. an httptest.Server.
+	BaseURL *url.URL
 }
 
 func (c *RESTClient) Get() *restclient.Request {
@@ -102,7 +106,11 @@ func (c *RESTClient) Request() *restclient.Request {
 		GroupVersion: c.GroupVersion,
 		Negotiator:   runtime.NewClientNegotiator(c.NegotiatedSerializer, c.GroupVersion),
 	}
-	return restclient.NewRequestWithClient(&url.URL{Scheme: "https", Host: "localhost"}, c.VersionedAPIPath, config, CreateHTTPClient(c.do))
+	baseURL := c.BaseURL
+	if baseURL == nil {
+		baseURL = &url.URL{Scheme: "https", Host: "localhost"}
+	}
+	return restclient.NewRequestWithClient(baseURL, c.VersionedAPIPath, config, CreateHTTPClient(c.do))
 }
 
 // do is invoked when a Request() created by this client is executed.
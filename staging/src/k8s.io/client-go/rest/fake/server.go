@@ -0,0 +1,109 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// RecordedRequest is a request served by a ServerClient, captured together
+// with its body. The body of the embedded request has already been consumed.
+type RecordedRequest struct {
+	*http.Request
+	// Body is the full request body, or nil if the request had none.
+	Body []byte
+}
+
+// ServerClient is a fake RESTClient backed by a real httptest.Server. Unlike
+// a plain RESTClient with a canned Resp, requests travel through the complete
+// net/http client and server stack, which exercises body streaming, header
+// canonicalization, and connection behavior that a RoundTripper-level fake
+// cannot. Every request served is recorded and available via Requests.
+//
+// Callers must Close the client when finished to release the server.
+type ServerClient struct {
+	*RESTClient
+	server *httptest.Server
+
+	lock     sync.Mutex
+	requests []RecordedRequest
+}
+
+// NewServerClient returns a ServerClient whose requests are served by handler
+// running in an httptest.Server. A nil handler serves an empty 200 response
+// for every request.
+func NewServerClient(groupVersion schema.GroupVersion, negotiatedSerializer runtime.NegotiatedSerializer, versionedAPIPath string, handler http.Handler) (*ServerClient, error) {
+	if handler == nil {
+		handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+	}
+
+	c := &ServerClient{}
+	c.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		recorded := RecordedRequest{Request: req.Clone(req.Context())}
+		if req.Body != nil {
+			body, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			recorded.Body = body
+			// Recording consumed the body; restore it so the handler sees
+			// the request unchanged.
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+		c.lock.Lock()
+		c.requests = append(c.requests, recorded)
+		c.lock.Unlock()
+		handler.ServeHTTP(w, req)
+	}))
+
+	baseURL, err := url.Parse(c.server.URL)
+	if err != nil {
+		c.server.Close()
+		return nil, err
+	}
+	c.RESTClient = &RESTClient{
+		NegotiatedSerializer: negotiatedSerializer,
+		GroupVersion:         groupVersion,
+		VersionedAPIPath:     versionedAPIPath,
+		Client:               c.server.Client(),
+		BaseURL:              baseURL,
+	}
+	return c, nil
+}
+
+// Requests returns a copy of all requests served so far, in order.
+func (c *ServerClient) Requests() []RecordedRequest {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return append([]RecordedRequest(nil), c.requests...)
+}
+
+// Close shuts down the backing server.
+func (c *ServerClient) Close() {
+	c.server.Close()
+}
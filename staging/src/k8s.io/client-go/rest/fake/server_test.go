@@ -0,0 +1,87 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+func TestServerClientRecordsRequests(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Success"}`))
+	})
+	client, err := NewServerClient(schema.GroupVersion{Version: "v1"}, serializer.WithoutConversionCodecFactory{CodecFactory: scheme.Codecs}, "/api/v1", handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	if err := client.Get().Resource("pods").Name("foo").Do(context.TODO()).Error(); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.Post().Resource("pods").Body([]byte(`{"kind":"Pod"}`)).Do(context.TODO()).Error(); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := client.Requests()
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 recorded requests, got %d", len(requests))
+	}
+	if requests[0].Method != http.MethodGet || requests[0].URL.Path != "/api/v1/pods/foo" {
+		t.Errorf("unexpected first request: %s %s", requests[0].Method, requests[0].URL.Path)
+	}
+	if requests[1].Method != http.MethodPost {
+		t.Errorf("unexpected second request method: %s", requests[1].Method)
+	}
+	if string(requests[1].Body) != `{"kind":"Pod"}` {
+		t.Errorf("unexpected second request body: %q", string(requests[1].Body))
+	}
+}
+
+func TestServerClientPreservesRequestBodyForHandler(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(body)
+	})
+	client, err := NewServerClient(schema.GroupVersion{Version: "v1"}, serializer.WithoutConversionCodecFactory{CodecFactory: scheme.Codecs}, "/api/v1", handler)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	sent := `{"kind":"Status","apiVersion":"v1","status":"Success","message":"echo"}`
+	echoed, err := client.Post().Resource("pods").Body([]byte(sent)).Do(context.TODO()).Raw()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(echoed) != sent {
+		t.Errorf("expected the handler to see the recorded body, got response %q", string(echoed))
+	}
+}
@@ -38,6 +38,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	serializerjson "k8s.io/apimachinery/pkg/runtime/serializer/json"
 	"k8s.io/apimachinery/pkg/runtime/serializer/streaming"
 	utilclock "k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/apimachinery/pkg/util/net"
@@ -108,6 +109,14 @@ type Request struct {
 	resourceName string
 	subresource  string
 
+	// watchEventDecodeErrorHandler, if set, keeps watch streams alive across
+	// events whose embedded object cannot be decoded.
+	watchEventDecodeErrorHandler restclientwatch.EventDecodeErrorHandler
+
+	// streamingDecodeThreshold overrides the minimum response size, in bytes,
+	// at which DoInto switches to streaming decoding. Zero means the default.
+	streamingDecodeThreshold int64
+
 	// output
 	err   error
 	body  io.Reader
@@ -412,6 +421,23 @@ func (r *Request) MaxRetries(maxRetries int) *Request {
 	return r
 }
 
+// WatchEventDecodeErrorHandler makes watch streams started by this request
+// skip events whose embedded object cannot be decoded, reporting each skipped
+// event to the given handler instead of terminating the watch. By default an
+// undecodable event ends the watch with an error.
+func (r *Request) WatchEventDecodeErrorHandler(handler restclientwatch.EventDecodeErrorHandler) *Request {
+	r.watchEventDecodeErrorHandler = handler
+	return r
+}
+
+// StreamingDecodeThreshold sets the minimum response body size, in bytes, at
+// which DoInto decodes the response as it is read instead of buffering it in
+// full first. Zero selects the default threshold.
+func (r *Request) StreamingDecodeThreshold(bytes int64) *Request {
+	r.streamingDecodeThreshold = bytes
+	return r
+}
+
 // Body makes the request use obj as the body. Optional.
 // If obj is a string, try to read a file of that name.
 // If obj is a []byte, send it directly.
@@ -602,6 +628,9 @@ func (r *Request) tryThrottleWithInfo(ctx context.Context, retryInfo string) err
 		globalThrottledLogger.Infof("%s", message)
 	}
 	metrics.RateLimiterLatency.Observe(ctx, r.verb, r.finalURLTemplate(), latency)
+	if t := throttleLatencyTrackerFrom(ctx); t != nil {
+		t.observeRateLimiterWait(latency)
+	}
 
 	return err
 }
@@ -709,6 +738,7 @@ func (r *Request) Watch(ctx context.Context) (watch.Interface, error) {
 
 		resp, err := client.Do(req)
 		updateURLMetrics(ctx, r, resp, err)
+		r.observeResponseForRateLimiter(resp)
 		if r.c.base != nil {
 			if err != nil {
 				r.backoff.UpdateBackoff(r.c.base, err, 0)
@@ -771,15 +801,32 @@ func (r *Request) newStreamWatcher(resp *http.Response) (watch.Interface, error)
 
 	frameReader := framer.NewFrameReader(resp.Body)
 	watchEventDecoder := streaming.NewDecoder(frameReader, streamingSerializer)
+	var eventDecoder watch.Decoder
+	if r.watchEventDecodeErrorHandler != nil {
+		eventDecoder = restclientwatch.NewDecoderWithErrorHandler(watchEventDecoder, objectDecoder, r.watchEventDecodeErrorHandler)
+	} else {
+		eventDecoder = restclientwatch.NewDecoder(watchEventDecoder, objectDecoder)
+	}
 
 	return watch.NewStreamWatcher(
-		restclientwatch.NewDecoder(watchEventDecoder, objectDecoder),
+		eventDecoder,
 		// use 500 to indicate that the cause of the error is unknown - other error codes
 		// are more specific to HTTP interactions, and set a reason
 		errors.NewClientErrorReporter(http.StatusInternalServerError, r.verb, "ClientWatchDecoding"),
 	), nil
 }
 
+// observeResponseForRateLimiter feeds the response status code back to rate
+// limiters that adapt to server throttling signals.
+func (r *Request) observeResponseForRateLimiter(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	if observer, ok := r.rateLimiter.(flowcontrol.ResponseObservingRateLimiter); ok {
+		observer.ObserveResponse(resp.StatusCode)
+	}
+}
+
 // updateURLMetrics is a convenience function for pushing metrics.
 // It also handles corner cases for incomplete/invalid request data.
 func updateURLMetrics(ctx context.Context, req *Request, resp *http.Response, err error) {
@@ -840,6 +887,7 @@ func (r *Request) Stream(ctx context.Context) (io.ReadCloser, error) {
 
 		resp, err := client.Do(req)
 		updateURLMetrics(ctx, r, resp, err)
+		r.observeResponseForRateLimiter(resp)
 		if r.c.base != nil {
 			if err != nil {
 				r.backoff.UpdateBackoff(r.URL(), err, 0)
@@ -979,6 +1027,7 @@ func (r *Request) request(ctx context.Context, fn func(*http.Request, *http.Resp
 		}
 		resp, err := client.Do(req)
 		updateURLMetrics(ctx, r, resp, err)
+		r.observeResponseForRateLimiter(resp)
 		if err != nil {
 			r.backoff.UpdateBackoff(r.URL(), err, 0)
 		} else {
@@ -1044,6 +1093,69 @@ func (r *Request) Do(ctx context.Context) Result {
 	return result
 }
 
+// defaultStreamingDecodeThreshold is the response size at which DoInto
+// switches from buffered to streaming decoding. Below this size double
+// buffering is cheap and the regular path preserves full content-type
+// negotiation.
+const defaultStreamingDecodeThreshold = 10 * 1024 * 1024 // 10MB
+
+// streamingJSONIterator decodes streamed responses with the same case-sensitive
+// field matching the negotiated serializers use for buffered responses, so
+// decoding behaves identically on both sides of the streaming threshold.
+var streamingJSONIterator = serializerjson.CaseSensitiveJSONIterator()
+
+// DoInto formats and executes the request, decoding the response body directly
+// into obj. It behaves like Do(ctx).Into(obj), except that large 2xx JSON
+// responses of known size are decoded from the wire as they arrive instead of
+// being buffered in full before decoding, which roughly halves peak memory
+// when retrieving very large single objects. Responses smaller than the
+// streaming threshold, responses of unknown length, and non-JSON responses use
+// the regular buffered path. The request's Accept header is forced to
+// application/json so the response is always streamable.
+func (r *Request) DoInto(ctx context.Context, obj runtime.Object) error {
+	r.SetHeader("Accept", runtime.ContentTypeJSON)
+
+	threshold := r.streamingDecodeThreshold
+	if threshold <= 0 {
+		threshold = defaultStreamingDecodeThreshold
+	}
+
+	var result Result
+	var streamed bool
+	var streamErr error
+	err := r.request(ctx, func(req *http.Request, resp *http.Response) {
+		if !canStreamDecodeResponse(resp, threshold) {
+			result = r.transformResponse(resp, req)
+			return
+		}
+		streamed = true
+		handleWarnings(resp.Header, r.warningHandler)
+		if err := streamingJSONIterator.NewDecoder(resp.Body).Decode(obj); err != nil {
+			streamErr = fmt.Errorf("streaming decode of %d byte response failed: %w", resp.ContentLength, err)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if streamed {
+		return streamErr
+	}
+	return result.Into(obj)
+}
+
+// canStreamDecodeResponse reports whether the response is a successful JSON
+// response of known size at or above the streaming threshold.
+func canStreamDecodeResponse(resp *http.Response, threshold int64) bool {
+	if resp.StatusCode < http.StatusOK || resp.StatusCode > http.StatusPartialContent {
+		return false
+	}
+	if resp.ContentLength < threshold {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return err == nil && mediaType == runtime.ContentTypeJSON
+}
+
 // DoRaw executes the request but does not process the response body.
 func (r *Request) DoRaw(ctx context.Context) ([]byte, error) {
 	var result Result
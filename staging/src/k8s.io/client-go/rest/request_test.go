@@ -30,6 +30,7 @@ import (
 	"net/url"
 	"os"
 	"reflect"
+	"strconv"
 	"strings"
 	"sync"
 	"syscall"
@@ -2944,3 +2945,138 @@ func testRequestWithRetry(t *testing.T, key string, doFunc func(ctx context.Cont
 		})
 	}
 }
+
+func TestDoIntoStreamsLargeResponses(t *testing.T) {
+	expected := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "big", Namespace: "test"},
+		Data:       map[string]string{"payload": strings.Repeat("x", 256*1024)},
+	}
+	body, err := runtime.Encode(scheme.Codecs.LegacyCodec(v1.SchemeGroupVersion), expected)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	}))
+	defer testServer.Close()
+
+	u, _ := url.Parse(testServer.URL)
+	for _, threshold := range []int64{1, int64(len(body)) * 2} {
+		got := &v1.ConfigMap{}
+		err := NewRequestWithClient(u, "", defaultContentConfig(), http.DefaultClient).
+			Verb("GET").
+			StreamingDecodeThreshold(threshold).
+			DoInto(context.Background(), got)
+		if err != nil {
+			t.Fatalf("threshold %d: unexpected error: %v", threshold, err)
+		}
+		if got.Name != expected.Name || got.Data["payload"] != expected.Data["payload"] {
+			t.Errorf("threshold %d: decoded object does not match", threshold)
+		}
+	}
+}
+
+func TestDoIntoStreamingDecodeIsCaseSensitive(t *testing.T) {
+	// a wrongly cased field must be ignored by the streaming decoder the same
+	// way the buffered codec ignores it
+	body := []byte(`{"kind":"ConfigMap","apiVersion":"v1","metadata":{"Name":"wrong-case","namespace":"test"},"data":{"key":"value"}}`)
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	}))
+	defer testServer.Close()
+
+	u, _ := url.Parse(testServer.URL)
+	for _, threshold := range []int64{1, int64(len(body)) * 2} {
+		got := &v1.ConfigMap{}
+		err := NewRequestWithClient(u, "", defaultContentConfig(), http.DefaultClient).
+			Verb("GET").
+			StreamingDecodeThreshold(threshold).
+			DoInto(context.Background(), got)
+		if err != nil {
+			t.Fatalf("threshold %d: unexpected error: %v", threshold, err)
+		}
+		if got.Name != "" {
+			t.Errorf("threshold %d: expected the wrongly cased metadata.Name to be ignored, got %q", threshold, got.Name)
+		}
+		if got.Namespace != "test" || got.Data["key"] != "value" {
+			t.Errorf("threshold %d: correctly cased fields were not decoded: %#v", threshold, got)
+		}
+	}
+}
+
+func TestDoIntoReturnsAPIErrors(t *testing.T) {
+	status := apierrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "missing").ErrStatus
+	body, err := runtime.Encode(scheme.Codecs.LegacyCodec(v1.SchemeGroupVersion), &status)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write(body)
+	}))
+	defer testServer.Close()
+
+	u, _ := url.Parse(testServer.URL)
+	got := &v1.ConfigMap{}
+	// even with a threshold of 1, error responses must take the buffered path
+	// so they can be converted into structured API errors
+	err = NewRequestWithClient(u, "", defaultContentConfig(), http.DefaultClient).
+		Verb("GET").
+		StreamingDecodeThreshold(1).
+		DoInto(context.Background(), got)
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected a NotFound error, got %v", err)
+	}
+}
+
+func BenchmarkDoIntoLargeObject(b *testing.B) {
+	obj := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "big", Namespace: "test"},
+		Data:       map[string]string{"payload": strings.Repeat("x", 16*1024*1024)},
+	}
+	body, err := runtime.Encode(scheme.Codecs.LegacyCodec(v1.SchemeGroupVersion), obj)
+	if err != nil {
+		b.Fatalf("unexpected error: %v", err)
+	}
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.WriteHeader(http.StatusOK)
+		w.Write(body)
+	}))
+	defer testServer.Close()
+	u, _ := url.Parse(testServer.URL)
+
+	b.Run("buffered", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			got := &v1.ConfigMap{}
+			if err := NewRequestWithClient(u, "", defaultContentConfig(), http.DefaultClient).
+				Verb("GET").
+				StreamingDecodeThreshold(int64(len(body)) * 2).
+				DoInto(context.Background(), got); err != nil {
+				b.Fatalf("unexpected error: %v", err)
+			}
+		}
+	})
+	b.Run("streaming", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			got := &v1.ConfigMap{}
+			if err := NewRequestWithClient(u, "", defaultContentConfig(), http.DefaultClient).
+				Verb("GET").
+				StreamingDecodeThreshold(1).
+				DoInto(context.Background(), got); err != nil {
+				b.Fatalf("unexpected error: %v", err)
+			}
+		}
+	})
+}
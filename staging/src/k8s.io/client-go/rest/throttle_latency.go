@@ -0,0 +1,81 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rest
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ThrottleLatencyTracker accumulates the time a request (including its
+// retries) spent waiting on the client-side rate limiter and on
+// server-instructed Retry-After backoff. Attach one to a request context with
+// WithThrottleLatencyTracking to distinguish client-side queuing from server
+// time when investigating request latency.
+type ThrottleLatencyTracker struct {
+	mu              sync.Mutex
+	rateLimiterWait time.Duration
+	retryAfterWait  time.Duration
+}
+
+// RateLimiterWait returns the accumulated time spent waiting on the
+// client-side rate limiter.
+func (t *ThrottleLatencyTracker) RateLimiterWait() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.rateLimiterWait
+}
+
+// RetryAfterWait returns the accumulated time spent honoring Retry-After
+// responses from the server.
+func (t *ThrottleLatencyTracker) RetryAfterWait() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.retryAfterWait
+}
+
+func (t *ThrottleLatencyTracker) observeRateLimiterWait(d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rateLimiterWait += d
+}
+
+func (t *ThrottleLatencyTracker) observeRetryAfterWait(d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.retryAfterWait += d
+}
+
+type throttleLatencyKeyType int
+
+const throttleLatencyKey throttleLatencyKeyType = iota
+
+// WithThrottleLatencyTracking returns a context that carries a
+// ThrottleLatencyTracker, and the tracker itself. Requests issued with the
+// returned context record their throttle and Retry-After wait durations on
+// the tracker.
+func WithThrottleLatencyTracking(ctx context.Context) (context.Context, *ThrottleLatencyTracker) {
+	t := &ThrottleLatencyTracker{}
+	return context.WithValue(ctx, throttleLatencyKey, t), t
+}
+
+// throttleLatencyTrackerFrom returns the tracker attached to ctx, or nil.
+func throttleLatencyTrackerFrom(ctx context.Context) *ThrottleLatencyTracker {
+	t, _ := ctx.Value(throttleLatencyKey).(*ThrottleLatencyTracker)
+	return t
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rest
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestThrottleLatencyTracking(t *testing.T) {
+	ctx, tracker := WithThrottleLatencyTracking(context.Background())
+
+	if got := throttleLatencyTrackerFrom(ctx); got != tracker {
+		t.Fatalf("expected tracker from context, got %v", got)
+	}
+	if got := throttleLatencyTrackerFrom(context.Background()); got != nil {
+		t.Fatalf("expected no tracker on unrelated context, got %v", got)
+	}
+
+	tracker.observeRateLimiterWait(time.Second)
+	tracker.observeRateLimiterWait(2 * time.Second)
+	tracker.observeRetryAfterWait(5 * time.Second)
+
+	if got := tracker.RateLimiterWait(); got != 3*time.Second {
+		t.Errorf("expected rate limiter wait 3s, got %v", got)
+	}
+	if got := tracker.RetryAfterWait(); got != 5*time.Second {
+		t.Errorf("expected retry-after wait 5s, got %v", got)
+	}
+}
@@ -23,8 +23,14 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer/streaming"
 	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/metrics"
 )
 
+// EventDecodeErrorHandler is notified when the object embedded in a watch
+// event cannot be decoded, for example because an aggregator injected an
+// event with an unknown group/version/kind into the stream.
+type EventDecodeErrorHandler func(eventType watch.EventType, err error)
+
 // Decoder implements the watch.Decoder interface for io.ReadClosers that
 // have contents which consist of a series of watchEvent objects encoded
 // with the given streaming decoder. The internal objects will be then
@@ -32,6 +38,7 @@ import (
 type Decoder struct {
 	decoder         streaming.Decoder
 	embeddedDecoder runtime.Decoder
+	errorHandler    EventDecodeErrorHandler
 }
 
 // NewDecoder creates an Decoder for the given writer and codec.
@@ -42,28 +49,51 @@ func NewDecoder(decoder streaming.Decoder, embeddedDecoder runtime.Decoder) *Dec
 	}
 }
 
+// NewDecoderWithErrorHandler creates a Decoder that skips events whose
+// embedded object cannot be decoded instead of failing the stream. Each
+// skipped event is reported to errorHandler and counted by the
+// metrics.WatchEventsDropped metric. Errors decoding the event envelope
+// itself still terminate the stream.
+func NewDecoderWithErrorHandler(decoder streaming.Decoder, embeddedDecoder runtime.Decoder, errorHandler EventDecodeErrorHandler) *Decoder {
+	return &Decoder{
+		decoder:         decoder,
+		embeddedDecoder: embeddedDecoder,
+		errorHandler:    errorHandler,
+	}
+}
+
 // Decode blocks until it can return the next object in the reader. Returns an error
 // if the reader is closed or an object can't be decoded.
 func (d *Decoder) Decode() (watch.EventType, runtime.Object, error) {
-	var got metav1.WatchEvent
-	res, _, err := d.decoder.Decode(nil, &got)
-	if err != nil {
-		return "", nil, err
-	}
-	if res != &got {
-		return "", nil, fmt.Errorf("unable to decode to metav1.Event")
-	}
-	switch got.Type {
-	case string(watch.Added), string(watch.Modified), string(watch.Deleted), string(watch.Error), string(watch.Bookmark):
-	default:
-		return "", nil, fmt.Errorf("got invalid watch event type: %v", got.Type)
-	}
+	for {
+		var got metav1.WatchEvent
+		res, _, err := d.decoder.Decode(nil, &got)
+		if err != nil {
+			return "", nil, err
+		}
+		if res != &got {
+			return "", nil, fmt.Errorf("unable to decode to metav1.Event")
+		}
+		switch got.Type {
+		case string(watch.Added), string(watch.Modified), string(watch.Deleted), string(watch.Error), string(watch.Bookmark):
+		default:
+			return "", nil, fmt.Errorf("got invalid watch event type: %v", got.Type)
+		}
 
-	obj, err := runtime.Decode(d.embeddedDecoder, got.Object.Raw)
-	if err != nil {
-		return "", nil, fmt.Errorf("unable to decode watch event: %v", err)
+		obj, err := runtime.Decode(d.embeddedDecoder, got.Object.Raw)
+		if err != nil {
+			if d.errorHandler != nil {
+				// The event envelope decoded cleanly, so the stream is still
+				// aligned on frame boundaries and it is safe to continue with
+				// the next event.
+				metrics.WatchEventsDropped.Increment()
+				d.errorHandler(watch.EventType(got.Type), err)
+				continue
+			}
+			return "", nil, fmt.Errorf("unable to decode watch event: %v", err)
+		}
+		return watch.EventType(got.Type), obj, nil
 	}
-	return watch.EventType(got.Type), obj, nil
 }
 
 // Close closes the underlying r.
@@ -120,3 +120,55 @@ func TestDecoder_SourceClose(t *testing.T) {
 		t.Error("Timeout")
 	}
 }
+
+func TestDecoder_ErrorHandlerSkipsUndecodableEvents(t *testing.T) {
+	out, in := io.Pipe()
+
+	var skippedTypes []watch.EventType
+	var skippedErrs []error
+	decoder := restclientwatch.NewDecoderWithErrorHandler(streaming.NewDecoder(out, getDecoder()), getDecoder(), func(eventType watch.EventType, err error) {
+		skippedTypes = append(skippedTypes, eventType)
+		skippedErrs = append(skippedErrs, err)
+	})
+	defer decoder.Close()
+
+	expect := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "foo"}}
+	go func() {
+		encoder := json.NewEncoder(in)
+		// an event with an object of an unknown kind should be skipped
+		if err := encoder.Encode(&metav1.WatchEvent{
+			Type:   string(watch.Added),
+			Object: runtime.RawExtension{Raw: json.RawMessage(`{"apiVersion":"example.com/v1","kind":"Unknown"}`)},
+		}); err != nil {
+			t.Errorf("Unexpected error %v", err)
+		}
+		data, err := runtime.Encode(scheme.Codecs.LegacyCodec(v1.SchemeGroupVersion), expect)
+		if err != nil {
+			t.Errorf("Unexpected error %v", err)
+		}
+		if err := encoder.Encode(&metav1.WatchEvent{
+			Type:   string(watch.Modified),
+			Object: runtime.RawExtension{Raw: json.RawMessage(data)},
+		}); err != nil {
+			t.Errorf("Unexpected error %v", err)
+		}
+		in.Close()
+	}()
+
+	action, got, err := decoder.Decode()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if e, a := watch.Modified, action; e != a {
+		t.Errorf("Expected %v, got %v", e, a)
+	}
+	if e, a := expect, got; !apiequality.Semantic.DeepDerivative(e, a) {
+		t.Errorf("Expected %v, got %v", e, a)
+	}
+	if len(skippedTypes) != 1 || skippedTypes[0] != watch.Added {
+		t.Errorf("Expected one skipped Added event, got %v", skippedTypes)
+	}
+	if len(skippedErrs) != 1 || skippedErrs[0] == nil {
+		t.Errorf("Expected one skipped event error, got %v", skippedErrs)
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"net/http"
 	"time"
 
+	"k8s.io/client-go/tools/metrics"
 	"k8s.io/klog/v2"
 )
 
@@ -171,6 +172,10 @@ func (r *withRetry) BeforeNextRetry(ctx context.Context, backoff BackoffManager,
 	klog.V(4).Infof("Got a Retry-After %s response for attempt %d to %v", retryAfter.Wait, retryAfter.Attempt, url)
 	if backoff != nil {
 		backoff.Sleep(retryAfter.Wait)
+		metrics.RetryAfterLatency.Observe(retryAfter.Wait)
+		if t := throttleLatencyTrackerFrom(ctx); t != nil {
+			t.observeRetryAfterWait(retryAfter.Wait)
+		}
 	}
 	return nil
 }
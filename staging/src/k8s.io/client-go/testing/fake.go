@@ -17,6 +17,7 @@ limitations under the License.
 package testing
 
 import (
+	"context"
 	"fmt"
 	"sync"
 
@@ -33,6 +34,10 @@ type Fake struct {
 	sync.RWMutex
 	actions []Action // these may be castable to other types, but "Action" is the minimum
 
+	// actionsUpdated is closed and replaced each time an action is recorded,
+	// waking WaitForAction callers. It is created lazily by the first waiter.
+	actionsUpdated chan struct{}
+
 	// ReactionChain is the list of reactors that will be attempted for every
 	// request in the order they are tried.
 	ReactionChain []Reactor
@@ -136,7 +141,7 @@ func (c *Fake) Invokes(action Action, defaultReturnObj runtime.Object) (runtime.
 	defer c.Unlock()
 
 	actionCopy := action.DeepCopy()
-	c.actions = append(c.actions, action.DeepCopy())
+	c.recordAction(action.DeepCopy())
 	for _, reactor := range c.ReactionChain {
 		if !reactor.Handles(actionCopy) {
 			continue
@@ -160,7 +165,7 @@ func (c *Fake) InvokesWatch(action Action) (watch.Interface, error) {
 	defer c.Unlock()
 
 	actionCopy := action.DeepCopy()
-	c.actions = append(c.actions, action.DeepCopy())
+	c.recordAction(action.DeepCopy())
 	for _, reactor := range c.WatchReactionChain {
 		if !reactor.Handles(actionCopy) {
 			continue
@@ -184,7 +189,7 @@ func (c *Fake) InvokesProxy(action Action) restclient.ResponseWrapper {
 	defer c.Unlock()
 
 	actionCopy := action.DeepCopy()
-	c.actions = append(c.actions, action.DeepCopy())
+	c.recordAction(action.DeepCopy())
 	for _, reactor := range c.ProxyReactionChain {
 		if !reactor.Handles(actionCopy) {
 			continue
@@ -201,6 +206,59 @@ func (c *Fake) InvokesProxy(action Action) restclient.ResponseWrapper {
 	return nil
 }
 
+// recordAction appends the action to the history and wakes any WaitForAction
+// callers. The caller must hold the lock.
+func (c *Fake) recordAction(action Action) {
+	c.actions = append(c.actions, action)
+	if c.actionsUpdated != nil {
+		close(c.actionsUpdated)
+		c.actionsUpdated = nil
+	}
+}
+
+// WaitForAction blocks until an action matching matches has been recorded on
+// the fake client, or the context is done. Actions recorded before the call
+// are considered too, in order, so it is safe to start waiting after the
+// action may already have happened. It returns the first matching action, or
+// the context's error.
+func (c *Fake) WaitForAction(ctx context.Context, matches func(action Action) bool) (Action, error) {
+	index := 0
+	for {
+		c.Lock()
+		if index > len(c.actions) {
+			// ClearActions was called while we were waiting
+			index = 0
+		}
+		for ; index < len(c.actions); index++ {
+			if matches(c.actions[index]) {
+				action := c.actions[index]
+				c.Unlock()
+				return action, nil
+			}
+		}
+		if c.actionsUpdated == nil {
+			c.actionsUpdated = make(chan struct{})
+		}
+		updated := c.actionsUpdated
+		c.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-updated:
+		}
+	}
+}
+
+// WaitForVerb blocks until an action with the given verb and resource has
+// been recorded, using the same matching rules as Action.Matches, or the
+// context is done.
+func (c *Fake) WaitForVerb(ctx context.Context, verb, resource string) (Action, error) {
+	return c.WaitForAction(ctx, func(action Action) bool {
+		return action.Matches(verb, resource)
+	})
+}
+
 // ClearActions clears the history of actions called on the fake client.
 func (c *Fake) ClearActions() {
 	c.Lock()
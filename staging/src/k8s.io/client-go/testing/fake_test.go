@@ -17,12 +17,15 @@ limitations under the License.
 package testing
 
 import (
+	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 func TestOriginalObjectCaptured(t *testing.T) {
@@ -164,3 +167,59 @@ func TestReactorChangesPersisted(t *testing.T) {
 		t.Errorf("expected Action recorded to not be modified by ReactionFunc but it was")
 	}
 }
+
+func TestWaitForAction(t *testing.T) {
+	testResource := schema.GroupVersionResource{Group: "", Version: "test_version", Resource: "test_kind"}
+	newCreateAction := func(name string) CreateActionImpl {
+		return CreateActionImpl{
+			ActionImpl: ActionImpl{Verb: "create", Resource: testResource},
+			Object:     getArbitraryResource(testResource, name, "test_namespace"),
+		}
+	}
+
+	f := &Fake{}
+
+	// an already recorded action is found without waiting
+	if _, err := f.Invokes(newCreateAction("first"), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	action, err := f.WaitForVerb(context.Background(), "create", "test_kind")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if action.GetVerb() != "create" {
+		t.Errorf("expected create action, got %q", action.GetVerb())
+	}
+
+	// a later action wakes a concurrent waiter
+	result := make(chan error, 1)
+	go func() {
+		_, err := f.WaitForAction(context.Background(), func(action Action) bool {
+			create, ok := action.(CreateAction)
+			if !ok {
+				return false
+			}
+			accessor, err := meta.Accessor(create.GetObject())
+			return err == nil && accessor.GetName() == "second"
+		})
+		result <- err
+	}()
+	if _, err := f.Invokes(newCreateAction("second"), nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	select {
+	case err := <-result:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatal("timed out waiting for WaitForAction to observe the action")
+	}
+
+	// a waiter whose action never arrives returns the context's error
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := f.WaitForVerb(ctx, "delete", "test_kind"); err != context.DeadlineExceeded {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
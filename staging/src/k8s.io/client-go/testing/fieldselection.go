@@ -0,0 +1,120 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// FieldsFunc extracts the field selector fields from an object, mirroring the
+// per-resource GetAttrs functions the apiserver registers in its storage
+// layer.
+type FieldsFunc func(obj runtime.Object) fields.Set
+
+var (
+	fieldsFuncsLock sync.RWMutex
+	fieldsFuncs     = map[schema.GroupVersionResource]FieldsFunc{}
+)
+
+// RegisterFieldsFunc registers a FieldsFunc providing resource-specific field
+// selector fields for the given resource, in addition to the metadata.name
+// and metadata.namespace fields every object exposes. It is intended to be
+// called from test setup (or an init function of a fake package) before the
+// fake clientset is exercised.
+func RegisterFieldsFunc(gvr schema.GroupVersionResource, fn FieldsFunc) {
+	fieldsFuncsLock.Lock()
+	defer fieldsFuncsLock.Unlock()
+	fieldsFuncs[gvr] = fn
+}
+
+// DefaultObjectFields returns the field set every object exposes to field
+// selectors.
+func DefaultObjectFields(obj runtime.Object) fields.Set {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil
+	}
+	return fields.Set{
+		"metadata.name":      accessor.GetName(),
+		"metadata.namespace": accessor.GetNamespace(),
+	}
+}
+
+func objectFields(gvr schema.GroupVersionResource, obj runtime.Object) fields.Set {
+	fieldSet := DefaultObjectFields(obj)
+	fieldsFuncsLock.RLock()
+	fn := fieldsFuncs[gvr]
+	fieldsFuncsLock.RUnlock()
+	if fn != nil {
+		for key, value := range fn(obj) {
+			fieldSet[key] = value
+		}
+	}
+	return fieldSet
+}
+
+func matchesFieldSelector(gvr schema.GroupVersionResource, obj runtime.Object, selector fields.Selector) bool {
+	if selector == nil || selector.Empty() {
+		return true
+	}
+	fieldSet := objectFields(gvr, obj)
+	// Selectors referencing fields the fake does not expose pass every object
+	// through, preserving the historical behavior of ignoring field selectors
+	// rather than silently returning empty results.
+	for _, requirement := range selector.Requirements() {
+		if _, ok := fieldSet[requirement.Field]; !ok {
+			return true
+		}
+	}
+	return selector.Matches(fieldSet)
+}
+
+// FilterListByFieldSelector removes items not matching the field selector
+// from the given list, the same way the apiserver filters list results.
+func FilterListByFieldSelector(gvr schema.GroupVersionResource, list runtime.Object, selector fields.Selector) error {
+	if selector == nil || selector.Empty() {
+		return nil
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	matching := make([]runtime.Object, 0, len(items))
+	for _, item := range items {
+		if matchesFieldSelector(gvr, item, selector) {
+			matching = append(matching, item)
+		}
+	}
+	return meta.SetList(list, matching)
+}
+
+// FilterWatchByFieldSelector wraps a watch so that only events whose object
+// matches the field selector are delivered.
+func FilterWatchByFieldSelector(gvr schema.GroupVersionResource, w watch.Interface, selector fields.Selector) watch.Interface {
+	if selector == nil || selector.Empty() {
+		return w
+	}
+	return watch.Filter(w, func(in watch.Event) (watch.Event, bool) {
+		return in, matchesFieldSelector(gvr, in.Object, selector)
+	})
+}
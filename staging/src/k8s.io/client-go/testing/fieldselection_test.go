@@ -0,0 +1,168 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testing
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	serializer "k8s.io/apimachinery/pkg/runtime/serializer"
+)
+
+func TestListReactionHonorsFieldSelector(t *testing.T) {
+	testResource := schema.GroupVersionResource{Group: "", Version: "test_version", Resource: "test_kind"}
+	testKind := schema.GroupVersionKind{Group: "", Version: "test_version", Kind: "test_kind"}
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypeWithName(testKind, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(testKind.GroupVersion().WithKind(testKind.Kind+"List"), &unstructured.UnstructuredList{})
+	codecs := serializer.NewCodecFactory(scheme)
+	o := NewObjectTracker(scheme, codecs.UniversalDecoder())
+	for _, name := range []string{"one", "two"} {
+		if err := o.Create(testResource, getArbitraryResource(testResource, name, "test_namespace"), "test_namespace"); err != nil {
+			t.Fatalf("test resource creation failed: %v", err)
+		}
+	}
+	react := ObjectReaction(o)
+
+	action := NewListAction(testResource, testKind, "test_namespace", metav1.ListOptions{FieldSelector: "metadata.name=one"})
+	handled, list, err := react(action)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !handled {
+		t.Fatal("expected the list action to be handled")
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("expected 1 item matching the field selector, got %d", len(items))
+	}
+	accessor, err := meta.Accessor(items[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if accessor.GetName() != "one" {
+		t.Errorf("expected item %q, got %q", "one", accessor.GetName())
+	}
+}
+
+func TestListReactionRegisteredFieldsFunc(t *testing.T) {
+	testResource := schema.GroupVersionResource{Group: "", Version: "test_version", Resource: "test_field_kind"}
+	testKind := schema.GroupVersionKind{Group: "", Version: "test_version", Kind: "test_field_kind"}
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypeWithName(testKind, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(testKind.GroupVersion().WithKind(testKind.Kind+"List"), &unstructured.UnstructuredList{})
+	RegisterFieldsFunc(testResource, func(obj runtime.Object) fields.Set {
+		data, _ := obj.(*unstructured.Unstructured).Object["data"].(string)
+		return fields.Set{"data": data}
+	})
+	codecs := serializer.NewCodecFactory(scheme)
+	o := NewObjectTracker(scheme, codecs.UniversalDecoder())
+	obj := getArbitraryResource(testResource, "one", "test_namespace")
+	obj.Object["data"] = "match"
+	if err := o.Create(testResource, obj, "test_namespace"); err != nil {
+		t.Fatalf("test resource creation failed: %v", err)
+	}
+	other := getArbitraryResource(testResource, "two", "test_namespace")
+	other.Object["data"] = "other"
+	if err := o.Create(testResource, other, "test_namespace"); err != nil {
+		t.Fatalf("test resource creation failed: %v", err)
+	}
+	react := ObjectReaction(o)
+
+	_, list, err := react(NewListAction(testResource, testKind, "test_namespace", metav1.ListOptions{FieldSelector: "data=match"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 1 {
+		t.Fatalf("expected 1 item matching the registered field, got %d", len(items))
+	}
+}
+
+func TestListReactionIgnoresUnsupportedFieldSelector(t *testing.T) {
+	testResource := schema.GroupVersionResource{Group: "", Version: "test_version", Resource: "test_kind"}
+	testKind := schema.GroupVersionKind{Group: "", Version: "test_version", Kind: "test_kind"}
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypeWithName(testKind, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(testKind.GroupVersion().WithKind(testKind.Kind+"List"), &unstructured.UnstructuredList{})
+	codecs := serializer.NewCodecFactory(scheme)
+	o := NewObjectTracker(scheme, codecs.UniversalDecoder())
+	for _, name := range []string{"one", "two"} {
+		if err := o.Create(testResource, getArbitraryResource(testResource, name, "test_namespace"), "test_namespace"); err != nil {
+			t.Fatalf("test resource creation failed: %v", err)
+		}
+	}
+	react := ObjectReaction(o)
+
+	// a selector on a field the fake does not expose must not filter anything
+	// out, matching the historical behavior of ignoring field selectors
+	action := NewListAction(testResource, testKind, "test_namespace", metav1.ListOptions{FieldSelector: "spec.nodeName=node-1"})
+	_, list, err := react(action)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected all items to pass through an unsupported field selector, got %d", len(items))
+	}
+}
+
+func TestWatchReactionHonorsFieldSelector(t *testing.T) {
+	testResource := schema.GroupVersionResource{Group: "", Version: "test_version", Resource: "test_kind"}
+	scheme := runtime.NewScheme()
+	codecs := serializer.NewCodecFactory(scheme)
+	o := NewObjectTracker(scheme, codecs.UniversalDecoder())
+	react := ObjectWatchReaction(o)
+
+	handled, w, err := react(NewWatchAction(testResource, "test_namespace", metav1.ListOptions{FieldSelector: "metadata.name=wanted"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !handled {
+		t.Fatal("expected the watch action to be handled")
+	}
+	defer w.Stop()
+	go func() {
+		for _, name := range []string{"ignored", "wanted"} {
+			if err := o.Create(testResource, getArbitraryResource(testResource, name, "test_namespace"), "test_namespace"); err != nil {
+				t.Errorf("test resource creation failed: %v", err)
+			}
+		}
+	}()
+	out := <-w.ResultChan()
+	accessor, err := meta.Accessor(out.Object)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if accessor.GetName() != "wanted" {
+		t.Errorf("expected only the matching object to be delivered, got %q", accessor.GetName())
+	}
+}
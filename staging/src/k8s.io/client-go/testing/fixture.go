@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -88,7 +89,13 @@ func ObjectReaction(tracker ObjectTracker) ReactionFunc {
 
 		case ListActionImpl:
 			obj, err := tracker.List(gvr, action.GetKind(), ns)
-			return true, obj, err
+			if err != nil {
+				return true, nil, err
+			}
+			if err := FilterListByFieldSelector(gvr, obj, action.GetListRestrictions().Fields); err != nil {
+				return true, nil, err
+			}
+			return true, obj, nil
 
 		case GetActionImpl:
 			obj, err := tracker.Get(gvr, ns, action.GetName())
@@ -206,6 +213,12 @@ type tracker struct {
 	// watchers' channel. Note that too many unhandled events (currently 100,
 	// see apimachinery/pkg/watch.DefaultChanSize) will cause a panic.
 	watchers map[schema.GroupVersionResource]map[string][]*watch.RaceFreeFakeWatcher
+
+	// versioned indicates whether the tracker maintains apiserver-like
+	// resourceVersion semantics, assigning resourceVersion from the counter
+	// below on every write and rejecting stale updates.
+	versioned       bool
+	resourceVersion uint64
 }
 
 var _ ObjectTracker = &tracker{}
@@ -221,6 +234,24 @@ func NewObjectTracker(scheme ObjectScheme, decoder runtime.Decoder) ObjectTracke
 	}
 }
 
+// NewObjectTrackerWithResourceVersions returns an ObjectTracker that behaves
+// like NewObjectTracker and additionally maintains apiserver-like
+// resourceVersion semantics: every stored write is assigned a monotonically
+// increasing resourceVersion, updates carrying a resourceVersion that does not
+// match the stored object are rejected with a 409 Conflict, and lists report
+// the tracker's current resourceVersion in their list metadata. Updates with
+// an empty resourceVersion are applied unconditionally, matching the
+// apiserver. Useful for unit tests exercising optimistic-concurrency paths.
+func NewObjectTrackerWithResourceVersions(scheme ObjectScheme, decoder runtime.Decoder) ObjectTracker {
+	return &tracker{
+		scheme:    scheme,
+		decoder:   decoder,
+		objects:   make(map[schema.GroupVersionResource]map[types.NamespacedName]runtime.Object),
+		watchers:  make(map[schema.GroupVersionResource]map[string][]*watch.RaceFreeFakeWatcher),
+		versioned: true,
+	}
+}
+
 func (t *tracker) List(gvr schema.GroupVersionResource, gvk schema.GroupVersionKind, ns string) (runtime.Object, error) {
 	// Heuristic for list kind: original kind + List suffix. Might
 	// not always be true but this tracker has a pretty limited
@@ -257,6 +288,11 @@ func (t *tracker) List(gvr schema.GroupVersionResource, gvk schema.GroupVersionK
 	if err := meta.SetList(list, matchingObjs); err != nil {
 		return nil, err
 	}
+	if t.versioned {
+		if listMeta, err := meta.ListAccessor(list); err == nil {
+			listMeta.SetResourceVersion(strconv.FormatUint(t.resourceVersion, 10))
+		}
+	}
 	return list.DeepCopyObject(), nil
 }
 
@@ -397,8 +433,18 @@ func (t *tracker) add(gvr schema.GroupVersionResource, obj runtime.Object, ns st
 	}
 
 	namespacedName := types.NamespacedName{Namespace: newMeta.GetNamespace(), Name: newMeta.GetName()}
-	if _, ok = t.objects[gvr][namespacedName]; ok {
+	if existing, ok := t.objects[gvr][namespacedName]; ok {
 		if replaceExisting {
+			if t.versioned {
+				existingMeta, err := meta.Accessor(existing)
+				if err != nil {
+					return err
+				}
+				if rv := newMeta.GetResourceVersion(); len(rv) > 0 && rv != existingMeta.GetResourceVersion() {
+					return errors.NewConflict(gr, newMeta.GetName(), fmt.Errorf("the object has been modified; please apply your changes to the latest version and try again"))
+				}
+				t.assignResourceVersion(newMeta)
+			}
 			for _, w := range t.getWatches(gvr, ns) {
 				// To avoid the object from being accidentally modified by watcher
 				w.Modify(obj.DeepCopyObject())
@@ -414,6 +460,9 @@ func (t *tracker) add(gvr schema.GroupVersionResource, obj runtime.Object, ns st
 		return errors.NewNotFound(gr, newMeta.GetName())
 	}
 
+	if t.versioned {
+		t.assignResourceVersion(newMeta)
+	}
 	t.objects[gvr][namespacedName] = obj
 
 	for _, w := range t.getWatches(gvr, ns) {
@@ -424,6 +473,13 @@ func (t *tracker) add(gvr schema.GroupVersionResource, obj runtime.Object, ns st
 	return nil
 }
 
+// assignResourceVersion stamps the object with the next resourceVersion.
+// It must only be called with the tracker lock held for writing.
+func (t *tracker) assignResourceVersion(objMeta metav1.Object) {
+	t.resourceVersion++
+	objMeta.SetResourceVersion(strconv.FormatUint(t.resourceVersion, 10))
+}
+
 func (t *tracker) addList(obj runtime.Object, replaceExisting bool) error {
 	list, err := meta.ExtractList(obj)
 	if err != nil {
@@ -457,6 +513,10 @@ func (t *tracker) Delete(gvr schema.GroupVersionResource, ns, name string) error
 	}
 
 	delete(objs, namespacedName)
+	if t.versioned {
+		// deletes are writes and advance the tracker's resourceVersion.
+		t.resourceVersion++
+	}
 	for _, w := range t.getWatches(gvr, ns) {
 		w.Delete(obj.DeepCopyObject())
 	}
@@ -498,6 +558,21 @@ func DefaultWatchReactor(watchInterface watch.Interface, err error) WatchReactio
 	}
 }
 
+// ObjectWatchReaction returns a WatchReactionFunc that watches the given
+// tracker, honoring the field selector carried by the watch action.
+func ObjectWatchReaction(tracker ObjectTracker) WatchReactionFunc {
+	return func(action Action) (bool, watch.Interface, error) {
+		w, err := tracker.Watch(action.GetResource(), action.GetNamespace())
+		if err != nil {
+			return false, nil, err
+		}
+		if watchAction, ok := action.(WatchActionImpl); ok {
+			w = FilterWatchByFieldSelector(action.GetResource(), w, watchAction.GetWatchRestrictions().Fields)
+		}
+		return true, w, nil
+	}
+}
+
 // SimpleReactor is a Reactor.  Each reaction function is attached to a given verb,resource tuple.  "*" in either field matches everything for that value.
 // For instance, *,pods matches all verbs on pods.  This allows for easier composition of reaction functions
 type SimpleReactor struct {
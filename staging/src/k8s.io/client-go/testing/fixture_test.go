@@ -409,3 +409,78 @@ func Test_resourceCovers(t *testing.T) {
 		})
 	}
 }
+
+func TestTrackerResourceVersionSemantics(t *testing.T) {
+	testResource := schema.GroupVersionResource{Group: "", Version: "test_version", Resource: "test_kind"}
+	testKind := schema.GroupVersionKind{Group: "", Version: "test_version", Kind: "test_kind"}
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypeWithName(testKind, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(testKind.GroupVersion().WithKind(testKind.Kind+"List"), &unstructured.UnstructuredList{})
+	codecs := serializer.NewCodecFactory(scheme)
+	o := NewObjectTrackerWithResourceVersions(scheme, codecs.UniversalDecoder())
+
+	obj := getArbitraryResource(testResource, "test_name", "test_namespace")
+	if err := o.Create(testResource, obj, "test_namespace"); err != nil {
+		t.Fatalf("test resource creation failed: %v", err)
+	}
+
+	created, err := o.Get(testResource, "test_namespace", "test_name")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	accessor, err := meta.Accessor(created)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	firstRV := accessor.GetResourceVersion()
+	if _, err := strconv.ParseUint(firstRV, 10, 64); err != nil {
+		t.Fatalf("expected a numeric assigned resourceVersion, got %q", firstRV)
+	}
+
+	// an update with a stale resourceVersion is rejected with a conflict
+	stale := created.DeepCopyObject()
+	staleAccessor, _ := meta.Accessor(stale)
+	staleAccessor.SetResourceVersion("0")
+	if err := o.Update(testResource, stale, "test_namespace"); !errors.IsConflict(err) {
+		t.Errorf("expected a conflict error updating with a stale resourceVersion, got %v", err)
+	}
+
+	// an update with the current resourceVersion succeeds and bumps the version
+	if err := o.Update(testResource, created, "test_namespace"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	updated, err := o.Get(testResource, "test_namespace", "test_name")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	updatedAccessor, _ := meta.Accessor(updated)
+	if updatedAccessor.GetResourceVersion() == firstRV {
+		t.Errorf("expected the update to assign a new resourceVersion, still %q", firstRV)
+	}
+
+	// an update with no resourceVersion is applied unconditionally
+	unconditional := updated.DeepCopyObject()
+	unconditionalAccessor, _ := meta.Accessor(unconditional)
+	unconditionalAccessor.SetResourceVersion("")
+	if err := o.Update(testResource, unconditional, "test_namespace"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	// lists report the current resourceVersion
+	list, err := o.List(testResource, testKind, "test_namespace")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	listAccessor, err := meta.ListAccessor(list)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	latest, err := o.Get(testResource, "test_namespace", "test_name")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	latestAccessor, _ := meta.Accessor(latest)
+	if listAccessor.GetResourceVersion() != latestAccessor.GetResourceVersion() {
+		t.Errorf("expected list resourceVersion %q to match the latest write %q", listAccessor.GetResourceVersion(), latestAccessor.GetResourceVersion())
+	}
+}
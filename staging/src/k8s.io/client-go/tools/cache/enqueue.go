@@ -0,0 +1,74 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// EnqueueingEventHandler is a ResourceEventHandler that enqueues a work queue
+// key for every object it observes. Because a work queue deduplicates items
+// that are already pending, rapid sequences of events for the same object
+// collapse into a single work item. This is the boilerplate nearly every
+// controller writes by hand to connect an informer to its queue.
+type EnqueueingEventHandler struct {
+	queue   workqueue.Interface
+	keyFunc KeyFunc
+}
+
+// NewEnqueueingEventHandler returns a handler that enqueues the
+// namespace/name key of each observed object into queue, handling deletion
+// tombstones the same way controllers conventionally do.
+func NewEnqueueingEventHandler(queue workqueue.Interface) *EnqueueingEventHandler {
+	return NewEnqueueingEventHandlerWithKeyFunc(queue, DeletionHandlingMetaNamespaceKeyFunc)
+}
+
+// NewEnqueueingEventHandlerWithKeyFunc returns a handler that enqueues the
+// result of keyFunc for each observed object into queue.
+func NewEnqueueingEventHandlerWithKeyFunc(queue workqueue.Interface, keyFunc KeyFunc) *EnqueueingEventHandler {
+	return &EnqueueingEventHandler{queue: queue, keyFunc: keyFunc}
+}
+
+func (e *EnqueueingEventHandler) enqueue(obj interface{}) {
+	key, err := e.keyFunc(obj)
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("couldn't get key for object %+v: %v", obj, err))
+		return
+	}
+	e.queue.Add(key)
+}
+
+// OnAdd enqueues the key of the added object.
+func (e *EnqueueingEventHandler) OnAdd(obj interface{}) {
+	e.enqueue(obj)
+}
+
+// OnUpdate enqueues the key of the new version of the object.
+func (e *EnqueueingEventHandler) OnUpdate(oldObj, newObj interface{}) {
+	e.enqueue(newObj)
+}
+
+// OnDelete enqueues the key of the deleted object, unwrapping a deletion
+// tombstone if necessary.
+func (e *EnqueueingEventHandler) OnDelete(obj interface{}) {
+	e.enqueue(obj)
+}
+
+var _ ResourceEventHandler = &EnqueueingEventHandler{}
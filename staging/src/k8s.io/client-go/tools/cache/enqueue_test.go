@@ -0,0 +1,60 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+)
+
+func TestEnqueueingEventHandler(t *testing.T) {
+	queue := workqueue.New()
+	defer queue.ShutDown()
+	handler := NewEnqueueingEventHandler(queue)
+
+	pod := &testPod{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "name"}}
+
+	// repeated events for the same object deduplicate to a single queue entry
+	handler.OnAdd(pod)
+	handler.OnUpdate(pod, pod)
+	handler.OnDelete(pod)
+	if queue.Len() != 1 {
+		t.Fatalf("expected 1 queued key, got %d", queue.Len())
+	}
+	key, _ := queue.Get()
+	if key != "ns/name" {
+		t.Errorf("expected key ns/name, got %v", key)
+	}
+	queue.Done(key)
+
+	// deletion tombstones are unwrapped
+	handler.OnDelete(DeletedFinalStateUnknown{Key: "ns/tombstoned", Obj: pod})
+	if queue.Len() != 1 {
+		t.Fatalf("expected 1 queued key, got %d", queue.Len())
+	}
+	key, _ = queue.Get()
+	if key != "ns/tombstoned" {
+		t.Errorf("expected key ns/tombstoned, got %v", key)
+	}
+}
+
+// testPod carries just enough metadata for the key functions.
+type testPod struct {
+	metav1.ObjectMeta
+}
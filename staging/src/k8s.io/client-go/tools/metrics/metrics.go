@@ -53,6 +53,22 @@ type CallsMetric interface {
 	Increment(exitCode int, callStatus string)
 }
 
+// SizeMetric observes the size of a client-side cache.
+type SizeMetric interface {
+	Observe(size int)
+}
+
+// TransportCreateCallsMetric counts transport cache lookups partitioned by
+// result ("hit", "miss" or "uncacheable").
+type TransportCreateCallsMetric interface {
+	Increment(result string)
+}
+
+// CountMetric counts occurrences of an event.
+type CountMetric interface {
+	Increment()
+}
+
 var (
 	// ClientCertExpiry is the expiry time of a client certificate
 	ClientCertExpiry ExpiryMetric = noopExpiry{}
@@ -62,11 +78,22 @@ var (
 	RequestLatency LatencyMetric = noopLatency{}
 	// RateLimiterLatency is the client side rate limiter latency metric.
 	RateLimiterLatency LatencyMetric = noopLatency{}
+	// RetryAfterLatency is the aggregate time spent honoring server
+	// Retry-After responses.
+	RetryAfterLatency DurationMetric = noopDuration{}
 	// RequestResult is the result metric that rest clients will update.
 	RequestResult ResultMetric = noopResult{}
 	// ExecPluginCalls is the number of calls made to an exec plugin, partitioned by
 	// exit code and call status.
 	ExecPluginCalls CallsMetric = noopCalls{}
+	// TransportCacheEntries is the number of entries in the transport cache.
+	TransportCacheEntries SizeMetric = noopSize{}
+	// TransportCreateCalls is the number of transport cache lookups, partitioned
+	// by result.
+	TransportCreateCalls TransportCreateCallsMetric = noopTransportCreateCalls{}
+	// WatchEventsDropped is the number of undecodable watch events skipped by
+	// watch decoders configured to tolerate them.
+	WatchEventsDropped CountMetric = noopCount{}
 )
 
 // RegisterOpts contains all the metrics to register. Metrics may be nil.
@@ -75,8 +102,12 @@ type RegisterOpts struct {
 	ClientCertRotationAge DurationMetric
 	RequestLatency        LatencyMetric
 	RateLimiterLatency    LatencyMetric
+	RetryAfterLatency     DurationMetric
 	RequestResult         ResultMetric
 	ExecPluginCalls       CallsMetric
+	TransportCacheEntries SizeMetric
+	TransportCreateCalls  TransportCreateCallsMetric
+	WatchEventsDropped    CountMetric
 }
 
 // Register registers metrics for the rest client to use. This can
@@ -95,12 +126,24 @@ func Register(opts RegisterOpts) {
 		if opts.RateLimiterLatency != nil {
 			RateLimiterLatency = opts.RateLimiterLatency
 		}
+		if opts.RetryAfterLatency != nil {
+			RetryAfterLatency = opts.RetryAfterLatency
+		}
 		if opts.RequestResult != nil {
 			RequestResult = opts.RequestResult
 		}
 		if opts.ExecPluginCalls != nil {
 			ExecPluginCalls = opts.ExecPluginCalls
 		}
+		if opts.TransportCacheEntries != nil {
+			TransportCacheEntries = opts.TransportCacheEntries
+		}
+		if opts.TransportCreateCalls != nil {
+			TransportCreateCalls = opts.TransportCreateCalls
+		}
+		if opts.WatchEventsDropped != nil {
+			WatchEventsDropped = opts.WatchEventsDropped
+		}
 	})
 }
 
@@ -123,3 +166,15 @@ func (noopResult) Increment(context.Context, string, string, string) {}
 type noopCalls struct{}
 
 func (noopCalls) Increment(int, string) {}
+
+type noopSize struct{}
+
+func (noopSize) Observe(int) {}
+
+type noopTransportCreateCalls struct{}
+
+func (noopTransportCreateCalls) Increment(string) {}
+
+type noopCount struct{}
+
+func (noopCount) Increment() {}
@@ -17,6 +17,7 @@ limitations under the License.
 package remotecommand
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"net/http"
@@ -39,6 +40,17 @@ type StreamOptions struct {
 	Stderr            io.Writer
 	Tty               bool
 	TerminalSizeQueue TerminalSizeQueue
+
+	// StdinContext, StdoutContext and StderrContext optionally cancel
+	// forwarding of a single stream without terminating the session.
+	// Canceling StdinContext closes the remote stdin stream, so the remote
+	// process sees EOF while stdout and stderr keep draining; canceling
+	// StdoutContext or StderrContext resets the corresponding remote stream.
+	// A nil context means the stream lives as long as the session. Honored
+	// by streaming protocol v2 and newer.
+	StdinContext  context.Context
+	StdoutContext context.Context
+	StderrContext context.Context
 }
 
 // Executor is an interface for transporting shell-style streams.
@@ -48,6 +60,11 @@ type Executor interface {
 	// is set, the stderr stream is not used (raw TTY manages stdout and stderr over the
 	// stdout stream).
 	Stream(options StreamOptions) error
+
+	// StreamWithContext behaves as Stream, and additionally terminates the
+	// streaming session and closes the underlying connection when the provided
+	// context is canceled, returning the context error.
+	StreamWithContext(ctx context.Context, options StreamOptions) error
 }
 
 type streamCreator interface {
@@ -63,6 +80,10 @@ type streamExecutor struct {
 	upgrader  spdy.Upgrader
 	transport http.RoundTripper
 
+	// dialer, if set, is used to establish the connection instead of the
+	// transport/upgrader pair.
+	dialer httpstream.Dialer
+
 	method    string
 	url       *url.URL
 	protocols []string
@@ -103,24 +124,58 @@ func NewSPDYExecutorForProtocols(transport http.RoundTripper, upgrader spdy.Upgr
 	}, nil
 }
 
+// NewExecutorForDialer creates an Executor that negotiates a streaming
+// protocol over connections produced by the provided dialer. This allows
+// tests and tools that already hold a tunneled connection or a custom
+// transport to run the streaming protocols over it directly. If no protocols
+// are given, all supported protocols are offered, newest first.
+func NewExecutorForDialer(dialer httpstream.Dialer, protocols ...string) (Executor, error) {
+	if len(protocols) == 0 {
+		protocols = []string{
+			remotecommand.StreamProtocolV4Name,
+			remotecommand.StreamProtocolV3Name,
+			remotecommand.StreamProtocolV2Name,
+			remotecommand.StreamProtocolV1Name,
+		}
+	}
+	return &streamExecutor{
+		dialer:    dialer,
+		protocols: protocols,
+	}, nil
+}
+
 // Stream opens a protocol streamer to the server and streams until a client closes
 // the connection or the server disconnects.
 func (e *streamExecutor) Stream(options StreamOptions) error {
-	req, err := http.NewRequest(e.method, e.url.String(), nil)
+	return e.StreamWithContext(context.Background(), options)
+}
+
+// dial establishes a connection, via the dialer if one is set, or by
+// negotiating a SPDY upgrade over the configured transport otherwise.
+func (e *streamExecutor) dial(ctx context.Context) (httpstream.Connection, string, error) {
+	if e.dialer != nil {
+		return e.dialer.Dial(e.protocols...)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, e.method, e.url.String(), nil)
 	if err != nil {
-		return fmt.Errorf("error creating request: %v", err)
+		return nil, "", fmt.Errorf("error creating request: %v", err)
 	}
 
-	conn, protocol, err := spdy.Negotiate(
+	return spdy.Negotiate(
 		e.upgrader,
 		&http.Client{Transport: e.transport},
 		req,
 		e.protocols...,
 	)
+}
+
+// newConnectionAndStream creates a new SPDY connection and a stream protocol handler upon it.
+func (e *streamExecutor) newConnectionAndStream(ctx context.Context, options StreamOptions) (httpstream.Connection, streamProtocolHandler, error) {
+	conn, protocol, err := e.dial(ctx)
 	if err != nil {
-		return err
+		return nil, nil, err
 	}
-	defer conn.Close()
 
 	var streamer streamProtocolHandler
 
@@ -138,5 +193,37 @@ func (e *streamExecutor) Stream(options StreamOptions) error {
 		streamer = newStreamProtocolV1(options)
 	}
 
-	return streamer.stream(conn)
+	return conn, streamer, nil
+}
+
+// StreamWithContext opens a protocol streamer to the server and streams until a client closes
+// the connection, the server disconnects, or the provided context is canceled.
+func (e *streamExecutor) StreamWithContext(ctx context.Context, options StreamOptions) error {
+	conn, streamer, err := e.newConnectionAndStream(ctx, options)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	panicChan := make(chan interface{}, 1)
+	errorChan := make(chan error, 1)
+	go func() {
+		defer func() {
+			if p := recover(); p != nil {
+				panicChan <- p
+			}
+		}()
+		errorChan <- streamer.stream(conn)
+	}()
+
+	select {
+	case p := <-panicChan:
+		panic(p)
+	case err := <-errorChan:
+		return err
+	case <-ctx.Done():
+		// closing the connection unblocks the streaming goroutine
+		conn.Close()
+		return ctx.Err()
+	}
 }
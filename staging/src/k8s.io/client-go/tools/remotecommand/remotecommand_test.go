@@ -17,10 +17,14 @@ limitations under the License.
 package remotecommand
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"io/ioutil"
+	"reflect"
+
 	v1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -264,3 +268,72 @@ func v4WriteStatusFunc(stream io.Writer) func(status *apierrors.StatusError) err
 		return err
 	}
 }
+
+func TestStreamWithContextCancellation(t *testing.T) {
+	// an attacher that never finishes on its own
+	blockingAttacher := func(stdin io.Reader, stdout, stderr io.WriteCloser, tty bool, resize <-chan TerminalSize) error {
+		io.Copy(ioutil.Discard, stdin)
+		return nil
+	}
+	options := StreamOptions{Stdin: &fakeMassiveDataPty{}, Stdout: &fakeMassiveDataPty{}}
+	server := newTestHTTPServer(blockingAttacher, &options)
+	defer server.Close()
+
+	uri, _ := url.Parse(server.URL)
+	exec, err := NewSPDYExecutor(&rest.Config{Host: uri.Host}, "POST", uri)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- exec.StreamWithContext(ctx, options)
+	}()
+	cancel()
+
+	select {
+	case err := <-errCh:
+		if err != context.Canceled {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatal("timed out waiting for StreamWithContext to return after cancellation")
+	}
+}
+
+type fakeDialer struct {
+	dialed             bool
+	requestedProtocols []string
+	err                error
+}
+
+func (d *fakeDialer) Dial(protocols ...string) (httpstream.Connection, string, error) {
+	d.dialed = true
+	d.requestedProtocols = protocols
+	return nil, "", d.err
+}
+
+func TestExecutorForDialer(t *testing.T) {
+	dialer := &fakeDialer{err: fmt.Errorf("dial failed")}
+	exec, err := NewExecutorForDialer(dialer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = exec.Stream(StreamOptions{})
+	if err == nil || err.Error() != "dial failed" {
+		t.Errorf("expected the dialer error, got %v", err)
+	}
+	if !dialer.dialed {
+		t.Fatal("expected the custom dialer to be used")
+	}
+	expected := []string{
+		remotecommandconsts.StreamProtocolV4Name,
+		remotecommandconsts.StreamProtocolV3Name,
+		remotecommandconsts.StreamProtocolV2Name,
+		remotecommandconsts.StreamProtocolV1Name,
+	}
+	if !reflect.DeepEqual(dialer.requestedProtocols, expected) {
+		t.Errorf("expected all protocols to be offered, got %v", dialer.requestedProtocols)
+	}
+}
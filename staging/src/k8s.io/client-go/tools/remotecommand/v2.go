@@ -17,6 +17,7 @@ limitations under the License.
 package remotecommand
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -24,6 +25,7 @@ import (
 	"sync"
 
 	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
 	"k8s.io/apimachinery/pkg/util/runtime"
 )
 
@@ -38,6 +40,8 @@ type streamProtocolV2 struct {
 	remoteStdin  io.ReadWriteCloser
 	remoteStdout io.Reader
 	remoteStderr io.Reader
+
+	stdinCloseOnce sync.Once
 }
 
 var _ streamProtocolHandler = &streamProtocolV2{}
@@ -88,10 +92,52 @@ func (p *streamProtocolV2) createStreams(conn streamCreator) error {
 	return nil
 }
 
+// closeRemoteStdin closes the remote stdin stream exactly once, letting the
+// remote process observe EOF.
+func (p *streamProtocolV2) closeRemoteStdin() {
+	p.stdinCloseOnce.Do(func() { p.remoteStdin.Close() })
+}
+
+// watchStreamContexts tears down individual remote streams when their
+// optional per-stream contexts are canceled, leaving the rest of the session
+// running. done must be closed when the session ends to release the watchers.
+func (p *streamProtocolV2) watchStreamContexts(done <-chan struct{}) {
+	if p.remoteStdin != nil {
+		interruptStreamOnDone(p.StdinContext, done, p.closeRemoteStdin)
+	}
+	if p.remoteStdout != nil {
+		interruptStreamOnDone(p.StdoutContext, done, func() { resetStream(p.remoteStdout) })
+	}
+	if p.remoteStderr != nil {
+		interruptStreamOnDone(p.StderrContext, done, func() { resetStream(p.remoteStderr) })
+	}
+}
+
+// interruptStreamOnDone invokes stop when ctx is canceled before done closes.
+func interruptStreamOnDone(ctx context.Context, done <-chan struct{}, stop func()) {
+	if ctx == nil || ctx.Done() == nil {
+		return
+	}
+	go func() {
+		defer runtime.HandleCrash()
+		select {
+		case <-ctx.Done():
+			stop()
+		case <-done:
+		}
+	}()
+}
+
+// resetStream aborts both directions of a remote stream, unblocking pending
+// reads.
+func resetStream(r io.Reader) {
+	if s, ok := r.(httpstream.Stream); ok {
+		s.Reset()
+	}
+}
+
 func (p *streamProtocolV2) copyStdin() {
 	if p.Stdin != nil {
-		var once sync.Once
-
 		// copy from client's stdin to container's stdin
 		go func() {
 			defer runtime.HandleCrash()
@@ -99,7 +145,7 @@ func (p *streamProtocolV2) copyStdin() {
 			// if p.stdin is noninteractive, p.g. `echo abc | kubectl exec -i <pod> -- cat`, make sure
 			// we close remoteStdin as soon as the copy from p.stdin to remoteStdin finishes. Otherwise
 			// the executed command will remain running.
-			defer once.Do(func() { p.remoteStdin.Close() })
+			defer p.closeRemoteStdin()
 
 			if _, err := io.Copy(p.remoteStdin, readerWrapper{p.Stdin}); err != nil {
 				runtime.HandleError(err)
@@ -122,7 +168,7 @@ func (p *streamProtocolV2) copyStdin() {
 		// allow the copy in hijack to complete, and hijack to return.
 		go func() {
 			defer runtime.HandleCrash()
-			defer once.Do(func() { p.remoteStdin.Close() })
+			defer p.closeRemoteStdin()
 
 			// this "copy" doesn't actually read anything - it's just here to wait for
 			// the server to close remoteStdin.
@@ -177,6 +223,10 @@ func (p *streamProtocolV2) stream(conn streamCreator) error {
 
 	// now that all the streams have been created, proceed with reading & copying
 
+	done := make(chan struct{})
+	defer close(done)
+	p.watchStreamContexts(done)
+
 	errorChan := watchErrorStream(p.errorStream, &errorDecoderV2{})
 
 	p.copyStdin()
@@ -17,10 +17,12 @@ limitations under the License.
 package remotecommand
 
 import (
+	"context"
 	"errors"
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -226,3 +228,47 @@ func TestV2ErrorStreamReading(t *testing.T) {
 		}
 	}
 }
+
+type fakeRemoteStdin struct {
+	closed chan struct{}
+	once   sync.Once
+}
+
+func (f *fakeRemoteStdin) Read(p []byte) (int, error) {
+	<-f.closed
+	return 0, io.EOF
+}
+
+func (f *fakeRemoteStdin) Write(p []byte) (int, error) { return len(p), nil }
+
+func (f *fakeRemoteStdin) Close() error {
+	f.once.Do(func() { close(f.closed) })
+	return nil
+}
+
+func TestV2StdinContextCancelClosesRemoteStdin(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stdinReader, stdinWriter := io.Pipe()
+	defer stdinWriter.Close()
+
+	remoteStdin := &fakeRemoteStdin{closed: make(chan struct{})}
+	p := &streamProtocolV2{
+		StreamOptions: StreamOptions{Stdin: stdinReader, StdinContext: ctx},
+		remoteStdin:   remoteStdin,
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	p.watchStreamContexts(done)
+	p.copyStdin()
+
+	cancel()
+	select {
+	case <-remoteStdin.closed:
+		// the remote process sees EOF on stdin, the session keeps running
+	case <-time.After(wait.ForeverTestTimeout):
+		t.Fatal("expected the remote stdin stream to be closed when its context is canceled")
+	}
+}
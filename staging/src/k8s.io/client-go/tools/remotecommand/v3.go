@@ -89,6 +89,10 @@ func (p *streamProtocolV3) stream(conn streamCreator) error {
 
 	// now that all the streams have been created, proceed with reading & copying
 
+	done := make(chan struct{})
+	defer close(done)
+	p.watchStreamContexts(done)
+
 	errorChan := watchErrorStream(p.errorStream, &errorDecoderV3{})
 
 	p.handleResizes()
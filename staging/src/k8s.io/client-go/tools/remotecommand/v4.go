@@ -58,6 +58,10 @@ func (p *streamProtocolV4) stream(conn streamCreator) error {
 
 	// now that all the streams have been created, proceed with reading & copying
 
+	done := make(chan struct{})
+	defer close(done)
+	p.watchStreamContexts(done)
+
 	errorChan := watchErrorStream(p.errorStream, &errorDecoderV4{})
 
 	p.handleResizes()
@@ -26,6 +26,7 @@ import (
 
 	utilnet "k8s.io/apimachinery/pkg/util/net"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/metrics"
 )
 
 // TlsTransportCache caches TLS http.RoundTrippers different configurations. The
@@ -38,6 +39,16 @@ type tlsTransportCache struct {
 
 const idleConnsPerHost = 25
 
+const (
+	// transportCacheHit is recorded when a cached transport was reused.
+	transportCacheHit = "hit"
+	// transportCacheMiss is recorded when a new transport was created and cached.
+	transportCacheMiss = "miss"
+	// transportCacheUncacheable is recorded when the config cannot be cached
+	// (e.g. it carries a dial or proxy function).
+	transportCacheUncacheable = "uncacheable"
+)
+
 var tlsCache = &tlsTransportCache{transports: make(map[tlsCacheKey]*http.Transport)}
 
 type tlsCacheKey struct {
@@ -73,8 +84,12 @@ func (c *tlsTransportCache) get(config *Config) (http.RoundTripper, error) {
 
 		// See if we already have a custom transport for this config
 		if t, ok := c.transports[key]; ok {
+			metrics.TransportCreateCalls.Increment(transportCacheHit)
 			return t, nil
 		}
+		metrics.TransportCreateCalls.Increment(transportCacheMiss)
+	} else {
+		metrics.TransportCreateCalls.Increment(transportCacheUncacheable)
 	}
 
 	// Get the TLS options for this client config
@@ -121,11 +136,58 @@ func (c *tlsTransportCache) get(config *Config) (http.RoundTripper, error) {
 	if canCache {
 		// Cache a single transport for these options
 		c.transports[key] = transport
+		metrics.TransportCacheEntries.Observe(len(c.transports))
 	}
 
 	return transport, nil
 }
 
+// invalidate removes the cached transport for the given config, if any, and
+// closes its idle connections.
+func (c *tlsTransportCache) invalidate(config *Config) error {
+	key, canCache, err := tlsConfigKey(config)
+	if err != nil {
+		return err
+	}
+	if !canCache {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if t, ok := c.transports[key]; ok {
+		delete(c.transports, key)
+		t.CloseIdleConnections()
+		metrics.TransportCacheEntries.Observe(len(c.transports))
+	}
+	return nil
+}
+
+// invalidateAll drops every cached transport and closes their idle connections.
+func (c *tlsTransportCache) invalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, t := range c.transports {
+		delete(c.transports, key)
+		t.CloseIdleConnections()
+	}
+	metrics.TransportCacheEntries.Observe(len(c.transports))
+}
+
+// InvalidateCachedTransport removes the cached transport for the given config,
+// if any, and closes its idle connections. Long-running processes that cycle
+// through many distinct TLS configurations (e.g. rotating kubeconfigs) can use
+// this to release transports they will not use again.
+func InvalidateCachedTransport(config *Config) error {
+	return tlsCache.invalidate(config)
+}
+
+// InvalidateAllCachedTransports drops every cached transport and closes their
+// idle connections.
+func InvalidateAllCachedTransports() {
+	tlsCache.invalidateAll()
+}
+
 // tlsConfigKey returns a unique key for tls.Config objects returned from TLSConfigFor
 func tlsConfigKey(c *Config) (tlsCacheKey, bool, error) {
 	// Make sure ca/key/cert content is loaded
@@ -170,3 +170,44 @@ func TestTLSConfigKey(t *testing.T) {
 		}
 	}
 }
+
+func TestCacheInvalidation(t *testing.T) {
+	cache := &tlsTransportCache{transports: make(map[tlsCacheKey]*http.Transport)}
+
+	config := &Config{TLS: TLSConfig{Insecure: true, ServerName: "a"}}
+	rt, err := cache.get(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cache.transports) != 1 {
+		t.Fatalf("expected 1 cached transport, got %d", len(cache.transports))
+	}
+
+	// invalidating an uncached config is a no-op
+	if err := cache.invalidate(&Config{TLS: TLSConfig{Insecure: true, ServerName: "b"}}); err != nil {
+		t.Fatal(err)
+	}
+	if len(cache.transports) != 1 {
+		t.Fatalf("expected 1 cached transport, got %d", len(cache.transports))
+	}
+
+	// invalidating the cached config removes it, and the next get creates a new transport
+	if err := cache.invalidate(config); err != nil {
+		t.Fatal(err)
+	}
+	if len(cache.transports) != 0 {
+		t.Fatalf("expected empty cache, got %d entries", len(cache.transports))
+	}
+	rt2, err := cache.get(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rt == rt2 {
+		t.Error("expected a new transport after invalidation")
+	}
+
+	cache.invalidateAll()
+	if len(cache.transports) != 0 {
+		t.Fatalf("expected empty cache after invalidateAll, got %d entries", len(cache.transports))
+	}
+}
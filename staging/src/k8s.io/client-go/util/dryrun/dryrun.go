@@ -0,0 +1,116 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dryrun retrieves the server's view of the result of a change
+// without persisting it. Callers get back both the live object and the
+// object as the server would store it, and can compute a diff between the
+// two with whatever mechanism they prefer.
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+// Result holds the server's view of an object before and after a dry-run change.
+type Result struct {
+	// Live is the current object on the server, or nil if it does not exist.
+	Live *unstructured.Unstructured
+	// Merged is the object as the server would persist it if the change
+	// were applied for real.
+	Merged *unstructured.Unstructured
+}
+
+// Differ performs server-side dry-run requests against a single resource
+// and returns the live and merged versions of objects for diffing.
+type Differ struct {
+	// Client scopes requests to a single resource (and namespace, if namespaced).
+	Client dynamic.ResourceInterface
+	// FieldManager is the field manager name recorded for dry-run apply and
+	// update requests.
+	FieldManager string
+	// Force instructs dry-run apply requests to take ownership of
+	// conflicting fields rather than failing.
+	Force bool
+}
+
+// Apply performs a server-side apply of obj with dry-run enabled and
+// returns the live object together with the merged result.
+func (d *Differ) Apply(ctx context.Context, obj *unstructured.Unstructured) (*Result, error) {
+	live, err := d.live(ctx, obj.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal object for dry-run apply: %v", err)
+	}
+	merged, err := d.Client.Patch(ctx, obj.GetName(), types.ApplyPatchType, data, metav1.PatchOptions{
+		DryRun:       []string{metav1.DryRunAll},
+		FieldManager: d.FieldManager,
+		Force:        &d.Force,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Live: live, Merged: merged}, nil
+}
+
+// Update performs a dry-run update of obj, or a dry-run create if the
+// object does not exist, and returns the live object together with the
+// merged result.
+func (d *Differ) Update(ctx context.Context, obj *unstructured.Unstructured) (*Result, error) {
+	live, err := d.live(ctx, obj.GetName())
+	if err != nil {
+		return nil, err
+	}
+
+	var merged *unstructured.Unstructured
+	if live == nil {
+		merged, err = d.Client.Create(ctx, obj, metav1.CreateOptions{
+			DryRun:       []string{metav1.DryRunAll},
+			FieldManager: d.FieldManager,
+		})
+	} else {
+		merged, err = d.Client.Update(ctx, obj, metav1.UpdateOptions{
+			DryRun:       []string{metav1.DryRunAll},
+			FieldManager: d.FieldManager,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Live: live, Merged: merged}, nil
+}
+
+func (d *Differ) live(ctx context.Context, name string) (*unstructured.Unstructured, error) {
+	live, err := d.Client.Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return live, nil
+}
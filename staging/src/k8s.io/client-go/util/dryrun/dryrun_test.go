@@ -0,0 +1,79 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dryrun
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic/fake"
+)
+
+func newPod(name string, labels map[string]interface{}) *unstructured.Unstructured {
+	pod := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "ns",
+			},
+		},
+	}
+	if labels != nil {
+		pod.Object["metadata"].(map[string]interface{})["labels"] = labels
+	}
+	return pod
+}
+
+func TestDifferUpdate(t *testing.T) {
+	existing := newPod("existing", map[string]interface{}{"app": "old"})
+	scheme := runtime.NewScheme()
+	client := fake.NewSimpleDynamicClient(scheme, existing)
+	gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	differ := &Differ{Client: client.Resource(gvr).Namespace("ns"), FieldManager: "test-manager"}
+
+	// updating an existing object returns both live and merged versions
+	result, err := differ.Update(context.TODO(), newPod("existing", map[string]interface{}{"app": "new"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Live == nil {
+		t.Fatal("expected live object for existing pod")
+	}
+	if got := result.Live.GetLabels()["app"]; got != "old" {
+		t.Errorf("expected live label app=old, got %q", got)
+	}
+	if got := result.Merged.GetLabels()["app"]; got != "new" {
+		t.Errorf("expected merged label app=new, got %q", got)
+	}
+
+	// updating a missing object falls back to a dry-run create
+	result, err = differ.Update(context.TODO(), newPod("missing", map[string]interface{}{"app": "created"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Live != nil {
+		t.Errorf("expected nil live object for missing pod, got %v", result.Live)
+	}
+	if got := result.Merged.GetLabels()["app"]; got != "created" {
+		t.Errorf("expected merged label app=created, got %q", got)
+	}
+}
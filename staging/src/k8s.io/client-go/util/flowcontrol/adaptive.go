@@ -0,0 +1,136 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flowcontrol
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// ResponseObservingRateLimiter is a RateLimiter that adjusts its rate based
+// on responses observed from the server. Clients that recognize this
+// interface feed each response's status code back to the limiter.
+type ResponseObservingRateLimiter interface {
+	RateLimiter
+	// ObserveResponse feeds back the HTTP status code of a completed request.
+	ObserveResponse(statusCode int)
+}
+
+const (
+	// adaptiveBackoffDivisor bounds how far below the configured QPS the
+	// adaptive limiter will throttle itself.
+	adaptiveBackoffDivisor = 16
+	// adaptiveRecoveryFraction is the fraction of the configured QPS restored
+	// per recovery step after throttling ends.
+	adaptiveRecoveryFraction = 0.05
+	// adaptiveAdjustmentInterval is the minimum time between rate adjustments,
+	// so a burst of 429s from a single window is counted once.
+	adaptiveAdjustmentInterval = time.Second
+)
+
+type adaptiveRateLimiter struct {
+	limiter *rate.Limiter
+	clock   Clock
+
+	lock           sync.Mutex
+	maxQPS         float32
+	minQPS         float32
+	currentQPS     float32
+	lastAdjustment time.Time
+}
+
+// NewAdaptiveRateLimiter creates a token bucket rate limiter that reacts to
+// server throttling. It starts at the configured qps and stays within
+// [qps/16, qps]: each observed 429 (the signal API Priority and Fairness
+// uses to shed load) halves the current rate, and successful responses
+// slowly restore it. Adjustments happen at most once per second. Feed
+// responses to the limiter via ObserveResponse; rest.Request does this
+// automatically for the client's configured rate limiter.
+func NewAdaptiveRateLimiter(qps float32, burst int) ResponseObservingRateLimiter {
+	return newAdaptiveRateLimiter(qps, burst, realClock{})
+}
+
+func newAdaptiveRateLimiter(qps float32, burst int, c Clock) *adaptiveRateLimiter {
+	return &adaptiveRateLimiter{
+		limiter:    rate.NewLimiter(rate.Limit(qps), burst),
+		clock:      c,
+		maxQPS:     qps,
+		minQPS:     qps / adaptiveBackoffDivisor,
+		currentQPS: qps,
+	}
+}
+
+func (a *adaptiveRateLimiter) TryAccept() bool {
+	return a.limiter.AllowN(a.clock.Now(), 1)
+}
+
+// Accept will block until a token becomes available
+func (a *adaptiveRateLimiter) Accept() {
+	now := a.clock.Now()
+	a.clock.Sleep(a.limiter.ReserveN(now, 1).DelayFrom(now))
+}
+
+func (a *adaptiveRateLimiter) Stop() {
+}
+
+// QPS returns the current (adjusted) rate, not the configured maximum.
+func (a *adaptiveRateLimiter) QPS() float32 {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	return a.currentQPS
+}
+
+func (a *adaptiveRateLimiter) Wait(ctx context.Context) error {
+	return a.limiter.Wait(ctx)
+}
+
+// ObserveResponse adjusts the rate based on the observed status code:
+// multiplicative decrease on 429s, additive recovery on success.
+func (a *adaptiveRateLimiter) ObserveResponse(statusCode int) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	now := a.clock.Now()
+	if now.Sub(a.lastAdjustment) < adaptiveAdjustmentInterval {
+		return
+	}
+
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		a.setQPS(a.currentQPS/2, now)
+	case statusCode >= http.StatusOK && statusCode < http.StatusMultipleChoices:
+		if a.currentQPS < a.maxQPS {
+			a.setQPS(a.currentQPS+a.maxQPS*adaptiveRecoveryFraction, now)
+		}
+	}
+}
+
+func (a *adaptiveRateLimiter) setQPS(qps float32, now time.Time) {
+	if qps < a.minQPS {
+		qps = a.minQPS
+	}
+	if qps > a.maxQPS {
+		qps = a.maxQPS
+	}
+	a.currentQPS = qps
+	a.limiter.SetLimit(rate.Limit(qps))
+	a.lastAdjustment = now
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package flowcontrol
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+type fakeAdaptiveClock struct {
+	now time.Time
+}
+
+func (c *fakeAdaptiveClock) Now() time.Time        { return c.now }
+func (c *fakeAdaptiveClock) Sleep(d time.Duration) { c.now = c.now.Add(d) }
+func (c *fakeAdaptiveClock) step(d time.Duration)  { c.now = c.now.Add(d) }
+
+func TestAdaptiveRateLimiter(t *testing.T) {
+	clock := &fakeAdaptiveClock{now: time.Now()}
+	limiter := newAdaptiveRateLimiter(32, 10, clock)
+
+	if qps := limiter.QPS(); qps != 32 {
+		t.Fatalf("expected initial qps 32, got %v", qps)
+	}
+
+	// a 429 halves the rate
+	clock.step(2 * time.Second)
+	limiter.ObserveResponse(http.StatusTooManyRequests)
+	if qps := limiter.QPS(); qps != 16 {
+		t.Fatalf("expected qps 16 after 429, got %v", qps)
+	}
+
+	// adjustments are limited to one per interval
+	limiter.ObserveResponse(http.StatusTooManyRequests)
+	if qps := limiter.QPS(); qps != 16 {
+		t.Fatalf("expected qps unchanged within adjustment interval, got %v", qps)
+	}
+
+	// repeated 429s bottom out at maxQPS/16
+	for i := 0; i < 20; i++ {
+		clock.step(2 * time.Second)
+		limiter.ObserveResponse(http.StatusTooManyRequests)
+	}
+	if qps := limiter.QPS(); qps != 2 {
+		t.Fatalf("expected qps to bottom out at 2, got %v", qps)
+	}
+
+	// successes restore the rate gradually, capped at the configured maximum
+	clock.step(2 * time.Second)
+	limiter.ObserveResponse(http.StatusOK)
+	if qps := limiter.QPS(); qps != 2+32*adaptiveRecoveryFraction {
+		t.Fatalf("expected qps to recover by one step, got %v", qps)
+	}
+	for i := 0; i < 100; i++ {
+		clock.step(2 * time.Second)
+		limiter.ObserveResponse(http.StatusOK)
+	}
+	if qps := limiter.QPS(); qps != 32 {
+		t.Fatalf("expected qps to recover to 32, got %v", qps)
+	}
+}
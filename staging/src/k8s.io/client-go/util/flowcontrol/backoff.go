@@ -17,6 +17,8 @@ limitations under the License.
 package flowcontrol
 
 import (
+	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
@@ -35,6 +37,12 @@ type Backoff struct {
 	defaultDuration time.Duration
 	maxDuration     time.Duration
 	perItemBackoff  map[string]*backoffEntry
+	rand            *rand.Rand
+
+	// maxJitterFactor adds jitter to the exponentially backed off delay.
+	// If maxJitterFactor is zero, no jitter is added to the delay in
+	// order to maintain current behavior.
+	maxJitterFactor float64
 }
 
 func NewFakeBackOff(initial, max time.Duration, tc *clock.FakeClock) *Backoff {
@@ -47,11 +55,23 @@ func NewFakeBackOff(initial, max time.Duration, tc *clock.FakeClock) *Backoff {
 }
 
 func NewBackOff(initial, max time.Duration) *Backoff {
+	return NewBackOffWithJitter(initial, max, 0.0)
+}
+
+// NewBackOffWithJitter returns a Backoff that adds up to maxJitterFactor of
+// the current delay as random jitter on every step, so that many keys failing
+// at the same time (e.g. after a shared dependency recovers) do not retry in
+// lockstep. Old failures decay naturally: a key that stays quiet for twice
+// maxDuration restarts from the initial delay on its next failure.
+func NewBackOffWithJitter(initial, max time.Duration, maxJitterFactor float64) *Backoff {
+	clock := clock.RealClock{}
 	return &Backoff{
 		perItemBackoff:  map[string]*backoffEntry{},
-		Clock:           clock.RealClock{},
+		Clock:           clock,
 		defaultDuration: initial,
 		maxDuration:     max,
+		maxJitterFactor: maxJitterFactor,
+		rand:            rand.New(rand.NewSource(clock.Now().UnixNano())),
 	}
 }
 
@@ -74,8 +94,10 @@ func (p *Backoff) Next(id string, eventTime time.Time) {
 	entry, ok := p.perItemBackoff[id]
 	if !ok || hasExpired(eventTime, entry.lastUpdate, p.maxDuration) {
 		entry = p.initEntryUnsafe(id)
+		entry.backoff += p.jitter(entry.backoff)
 	} else {
-		delay := entry.backoff * 2 // exponential
+		delay := entry.backoff * 2       // exponential
+		delay += p.jitter(entry.backoff) // add some jitter to the delay
 		entry.backoff = time.Duration(integer.Int64Min(int64(delay), int64(p.maxDuration)))
 	}
 	entry.lastUpdate = p.Clock.Now()
@@ -136,6 +158,34 @@ func (p *Backoff) DeleteEntry(id string) {
 	delete(p.perItemBackoff, id)
 }
 
+func (p *Backoff) jitter(delay time.Duration) time.Duration {
+	if p.rand == nil {
+		return 0
+	}
+	return time.Duration(p.rand.Float64() * p.maxJitterFactor * float64(delay))
+}
+
+// BackoffRecord is an inspection snapshot of a single key's backoff state.
+type BackoffRecord struct {
+	Key        string
+	Backoff    time.Duration
+	LastUpdate time.Time
+}
+
+// Records returns a snapshot of all tracked keys sorted by key, for
+// inspection and debugging endpoints. The snapshot is a copy and can be read
+// without further locking.
+func (p *Backoff) Records() []BackoffRecord {
+	p.RLock()
+	defer p.RUnlock()
+	records := make([]BackoffRecord, 0, len(p.perItemBackoff))
+	for id, entry := range p.perItemBackoff {
+		records = append(records, BackoffRecord{Key: id, Backoff: entry.backoff, LastUpdate: entry.lastUpdate})
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].Key < records[j].Key })
+	return records
+}
+
 // Take a lock on *Backoff, before calling initEntryUnsafe
 func (p *Backoff) initEntryUnsafe(id string) *backoffEntry {
 	entry := &backoffEntry{backoff: p.defaultDuration}
@@ -193,3 +193,55 @@ func TestIsInBackOffSinceUpdate(t *testing.T) {
 		}
 	}
 }
+
+func TestBackoffWithJitter(t *testing.T) {
+	id := "_idJitter"
+	tc := clock.NewFakeClock(time.Now())
+	step := time.Second
+	maxDuration := 60 * step
+	b := NewBackOffWithJitter(step, maxDuration, 0.5)
+	b.Clock = tc
+
+	lower := step
+	for i := 0; i < 5; i++ {
+		tc.Step(b.Get(id) + step)
+		b.Next(id, tc.Now())
+		delay := b.Get(id)
+		// each step at least doubles the un-jittered delay and adds at most
+		// half of the previous delay as jitter
+		if delay < lower {
+			t.Errorf("step %d: delay %v smaller than un-jittered minimum %v", i, delay, lower)
+		}
+		if upper := time.Duration(float64(lower) * 1.5); delay > upper && delay < maxDuration {
+			t.Errorf("step %d: delay %v larger than jittered maximum %v", i, delay, upper)
+		}
+		lower = delay * 2
+		if lower > maxDuration {
+			lower = maxDuration
+		}
+	}
+}
+
+func TestBackoffRecords(t *testing.T) {
+	tc := clock.NewFakeClock(time.Now())
+	step := time.Second
+	b := NewFakeBackOff(step, 10*step, tc)
+
+	b.Next("b", tc.Now())
+	b.Next("a", tc.Now())
+	b.Next("a", tc.Now())
+
+	records := b.Records()
+	if len(records) != 2 {
+		t.Fatalf("expected 2 records, got %d", len(records))
+	}
+	if records[0].Key != "a" || records[1].Key != "b" {
+		t.Errorf("expected records sorted by key, got %v", records)
+	}
+	if records[0].Backoff != 2*step {
+		t.Errorf("expected backoff %v for key a, got %v", 2*step, records[0].Backoff)
+	}
+	if records[0].LastUpdate != tc.Now() {
+		t.Errorf("unexpected last update time %v", records[0].LastUpdate)
+	}
+}
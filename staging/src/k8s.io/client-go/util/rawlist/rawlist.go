@@ -0,0 +1,57 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package rawlist retrieves list results without decoding the individual
+// items. Callers that only need a small part of some objects in a large list
+// can inspect the raw item bytes and decode individual items on demand,
+// instead of paying the allocation cost of fully decoding every item.
+package rawlist
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+)
+
+// List executes the prepared list request and returns the result as a
+// metav1.List whose Items hold the raw, undecoded item bytes. The request is
+// forced to JSON, since raw item extraction is not supported for protobuf
+// responses. List metadata (resourceVersion, continue) is decoded as usual.
+func List(ctx context.Context, req *rest.Request) (*metav1.List, error) {
+	data, err := req.SetHeader("Accept", "application/json").Do(ctx).Raw()
+	if err != nil {
+		return nil, err
+	}
+	list := &metav1.List{}
+	if err := json.Unmarshal(data, list); err != nil {
+		return nil, fmt.Errorf("failed to parse list response: %v", err)
+	}
+	return list, nil
+}
+
+// DecodeItem decodes a single raw item returned by List into the given typed
+// object using the provided decoder, typically the client scheme's universal
+// decoder.
+func DecodeItem(decoder runtime.Decoder, item runtime.RawExtension, into runtime.Object) error {
+	if len(item.Raw) == 0 {
+		return fmt.Errorf("no raw bytes to decode")
+	}
+	return runtime.DecodeInto(decoder, item.Raw, into)
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2021 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rawlist
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest/fake"
+)
+
+func TestListAndDecodeItem(t *testing.T) {
+	body := `{
+		"apiVersion": "v1",
+		"kind": "PodList",
+		"metadata": {"resourceVersion": "42", "continue": "next-token"},
+		"items": [
+			{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "pod-a", "namespace": "ns"}},
+			{"apiVersion": "v1", "kind": "Pod", "metadata": {"name": "pod-b", "namespace": "ns"}}
+		]
+	}`
+	client := &fake.RESTClient{
+		NegotiatedSerializer: scheme.Codecs.WithoutConversion(),
+		GroupVersion:         schema.GroupVersion{Version: "v1"},
+		Resp: &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+		},
+	}
+
+	list, err := List(context.Background(), client.Get().Resource("pods"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if client.Req.Header.Get("Accept") != "application/json" {
+		t.Errorf("expected the request to be forced to JSON, got accept %q", client.Req.Header.Get("Accept"))
+	}
+	if list.ResourceVersion != "42" || list.Continue != "next-token" {
+		t.Errorf("unexpected list metadata: %#v", list.ListMeta)
+	}
+	if len(list.Items) != 2 {
+		t.Fatalf("expected 2 raw items, got %d", len(list.Items))
+	}
+
+	pod := &v1.Pod{}
+	if err := DecodeItem(scheme.Codecs.UniversalDeserializer(), list.Items[1], pod); err != nil {
+		t.Fatal(err)
+	}
+	if pod.Name != "pod-b" || pod.Namespace != "ns" {
+		t.Errorf("unexpected decoded pod: %#v", pod.ObjectMeta)
+	}
+
+	if err := DecodeItem(scheme.Codecs.UniversalDeserializer(), list.Items[0], &v1.Service{}); err == nil {
+		t.Errorf("expected error decoding a pod item into a service")
+	}
+}
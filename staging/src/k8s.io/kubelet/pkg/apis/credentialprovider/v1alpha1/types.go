@@ -114,4 +114,12 @@ type AuthConfig struct {
 	// password is the password used for authenticating to the container registry
 	// An empty password is valid.
 	Password string `json:"password"`
+
+	// mirrors is a list of approved registry mirror endpoints, in the same
+	// match image key format as the auth map keys, that serve content for
+	// images matched by this entry. The kubelet registers the same credentials
+	// for each mirror, so image pulls can be redirected to a credentialed
+	// mirror without node-level registry configuration changes.
+	// +optional
+	Mirrors []string `json:"mirrors,omitempty"`
 }
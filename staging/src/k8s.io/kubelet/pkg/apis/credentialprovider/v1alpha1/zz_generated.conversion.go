@@ -72,6 +72,7 @@ func RegisterConversions(s *runtime.Scheme) error {
 func autoConvert_v1alpha1_AuthConfig_To_credentialprovider_AuthConfig(in *AuthConfig, out *credentialprovider.AuthConfig, s conversion.Scope) error {
 	out.Username = in.Username
 	out.Password = in.Password
+	out.Mirrors = *(*[]string)(unsafe.Pointer(&in.Mirrors))
 	return nil
 }
 
@@ -83,6 +84,7 @@ func Convert_v1alpha1_AuthConfig_To_credentialprovider_AuthConfig(in *AuthConfig
 func autoConvert_credentialprovider_AuthConfig_To_v1alpha1_AuthConfig(in *credentialprovider.AuthConfig, out *AuthConfig, s conversion.Scope) error {
 	out.Username = in.Username
 	out.Password = in.Password
+	out.Mirrors = *(*[]string)(unsafe.Pointer(&in.Mirrors))
 	return nil
 }
 